@@ -7,6 +7,7 @@ import (
 	"io"
 	"log"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/xenomote/json_matcher/pattern"
@@ -17,11 +18,13 @@ const (
 	oArg = "o"
 	pArg = "p"
 	fArg = "f"
+	aArg = "a"
 )
 
 type options struct {
 	in, pat io.Reader
 	out     io.Writer
+	all     bool
 }
 
 func main() {
@@ -45,15 +48,17 @@ func main() {
 	enc := json.NewEncoder(output)
 
 	for lines.Scan() {
-		b, err := p.Interpret(lines.Text())
+		solutions, err := o.interpret(p, lines.Text())
 		if err != nil {
 			log.Println(err)
 			continue
 		}
 
-		err = enc.Encode(b)
-		if err != nil {
-			log.Fatalln(err)
+		for _, b := range solutions {
+			err = enc.Encode(b)
+			if err != nil {
+				log.Fatalln(err)
+			}
 		}
 	}
 
@@ -70,6 +75,7 @@ func args(args []string) options {
 	f.String(oArg, "", "output `file` to write json bindings to")
 	f.String(pArg, "", "string `pattern` to match")
 	f.String(fArg, "", "`file` containing pattern to match")
+	f.Bool(aArg, false, "output every distinct set of bindings the pattern matches, one per line")
 	f.Parse(args)
 
 	f.Visit(func(f *flag.Flag) {
@@ -107,6 +113,9 @@ func args(args []string) options {
 			
 		case fArg:
 			o.pat, err = os.Open(value)
+
+		case aArg:
+			o.all, err = strconv.ParseBool(value)
 		}
 
 		if err != nil {
@@ -121,6 +130,19 @@ func args(args []string) options {
 	return o
 }
 
+func (o options) interpret(p pattern.Pattern, line string) ([]map[string]interface{}, error) {
+	if o.all {
+		return p.InterpretAll(line)
+	}
+
+	b, err := p.Interpret(line)
+	if err != nil {
+		return nil, err
+	}
+
+	return []map[string]interface{}{b}, nil
+}
+
 func (o options) inOr(r io.Reader) io.Reader {
 	if o.in != nil {
 		return o.in
@@ -0,0 +1,86 @@
+package pattern
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Alternation []Value
+
+func (a Alternation) Match(s []byte, bOld bindings) (bindings, error) {
+	return first(a, s, bOld)
+}
+
+func (a Alternation) Solve(s []byte, bOld bindings) ([]bindings, error) {
+	return all(a, s, bOld)
+}
+
+func (a Alternation) each(s []byte, bOld bindings, m mode, k continuation) error {
+	failures := []string{}
+	var rejected error
+
+	for _, alternative := range a {
+		var downstream error
+		err := m.each(alternative, s, bOld, func(matched bindings) error {
+			downstream = k(matched)
+			return downstream
+		})
+
+		if err == nil {
+			return nil
+		}
+
+		if err == downstream {
+			rejected = err
+			continue
+		}
+
+		failures = append(failures, fmt.Sprintf("%s: %s", alternative, err))
+	}
+
+	if rejected != nil {
+		return rejected
+	}
+
+	return fmt.Errorf("no alternative matched: %s", strings.Join(failures, "; "))
+}
+
+func (a Alternation) Validate(s set) error {
+	var first []string
+
+	for i, alternative := range a {
+		sAlt := copySet(s)
+
+		if value, ok := alternative.(Validator); ok {
+			if err := value.Validate(sAlt); err != nil {
+				return fmt.Errorf("in alternative %s: %s", alternative, err)
+			}
+		}
+
+		names := added(s, sAlt)
+
+		if i == 0 {
+			first = names
+			continue
+		}
+
+		if strings.Join(names, ",") != strings.Join(first, ",") {
+			return fmt.Errorf("alternative %s binds [%s] but %s binds [%s], every alternative must bind the same names", a[0], strings.Join(first, ", "), alternative, strings.Join(names, ", "))
+		}
+	}
+
+	for _, k := range first {
+		s[k] = true
+	}
+
+	return nil
+}
+
+func (a Alternation) String() string {
+	s := []string{}
+	for _, alternative := range a {
+		s = append(s, alternative.String())
+	}
+
+	return strings.Join(s, " | ")
+}
@@ -7,6 +7,20 @@ import (
 
 type Array struct {
 	Elements []Element
+
+	// Positional arrays were written without indices, their elements are
+	// indexed by their position in the pattern
+	Positional bool
+
+	// Exact arrays must contain exactly as many elements as they declare
+	Exact bool
+
+	// Unordered arrays match each positional element against a distinct
+	// element of the input in any order
+	Unordered bool
+
+	// Length, when present, constrains the number of elements in the array
+	Length *Length
 }
 
 type Element struct {
@@ -16,7 +30,7 @@ type Element struct {
 }
 
 type Index interface {
-	Index() (int, error)
+	Index(bindings) (int, error)
 	String() string
 }
 
@@ -24,12 +38,24 @@ func (a Array) Interpret(s string) (bindings, error) {
 	return a.Match([]byte(s), bindings{})
 }
 
-func (a Array) Match(s []byte, bOld bindings) (bindings, error) {
-	bCopy := bindings{}
-	for k, v := range bOld {
-		bCopy[k] = v
+func (a Array) InterpretAll(s string) ([]bindings, error) {
+	solutions, err := a.Solve([]byte(s), bindings{})
+	if err != nil {
+		return nil, err
 	}
 
+	return distinct(solutions), nil
+}
+
+func (a Array) Match(s []byte, bOld bindings) (bindings, error) {
+	return first(a, s, bOld)
+}
+
+func (a Array) Solve(s []byte, bOld bindings) ([]bindings, error) {
+	return all(a, s, bOld)
+}
+
+func (a Array) each(s []byte, bOld bindings, m mode, k continuation) error {
 	var input []json.RawMessage
 	err := json.Unmarshal(s, &input)
 	if err != nil {
@@ -38,51 +64,182 @@ func (a Array) Match(s []byte, bOld bindings) (bindings, error) {
 			input = "'" + string(s) + "'"
 		}
 
-		return nil, fmt.Errorf("%s could not be interpreted as an array", input)
+		return fmt.Errorf("%s could not be interpreted as an array", input)
 	}
 
-	bNew := bindings{}
-	for _, definition := range a.Elements {
-		index, err := definition.Index.Index()
-		if err != nil {
-			return nil, err
+	if a.Exact && len(input) != len(a.Elements) {
+		return fmt.Errorf("expected array of exactly %d elements but found %d", len(a.Elements), len(input))
+	}
+
+	if a.Length != nil {
+		if err := a.Length.check(len(input)); err != nil {
+			return err
+		}
+	}
+
+	if a.Unordered {
+		return a.assign(input, bOld, m, k)
+	}
+
+	return search(bOld, len(a.Elements), func(i int, b bindings, m mode, k continuation) error {
+		return a.Elements[i].match(input, b, m, k)
+	}, m, k)
+}
+
+// assign searches for ways to match each element's value against a distinct
+// element of the input, backtracking when a later value cannot be matched.
+func (a Array) assign(input []json.RawMessage, bOld bindings, m mode, k continuation) error {
+	used := make([]bool, len(input))
+	tried := set{}
+
+	var assign func(int, bindings) error
+	assign = func(i int, bNew bindings) error {
+		// the same bindings with the same elements used always lead to the
+		// same solutions, so each such state is only searched once
+		key, _ := json.Marshal(bNew)
+		state := fmt.Sprintf("%d:%v:%s", i, used, key)
+		if tried[state] {
+			return fmt.Errorf("no way to match")
 		}
+		tried[state] = true
 
-		prefix := ""
-		if definition.Index.String() != fmt.Sprint(index) {
-			prefix = definition.Index.String() + " = "
+		if i == len(a.Elements) {
+			return k(bNew)
 		}
 
-		if index >= len(input) {
-			if definition.Optional {
+		value := a.Elements[i].Value
+		failure := fmt.Errorf("no unused element matched %s", value)
+		for j, element := range input {
+			if used[j] {
 				continue
 			}
 
-			return nil, fmt.Errorf("array was not long enough to contain required index %s%d", prefix, index)
+			var downstream error
+			err := m.each(value, element, merge(bOld, bNew), func(matched bindings) error {
+				extended, err := extend(bOld, bNew, matched)
+				if err != nil {
+					downstream = err
+					return err
+				}
+
+				used[j] = true
+				downstream = assign(i+1, extended)
+				used[j] = false
+
+				return downstream
+			})
+
+			if err == nil {
+				return nil
+			}
+
+			if err == downstream {
+				failure = err
+			}
 		}
 
-		value := input[index]
-		matched_bindings, err := definition.Value.Match(value, bCopy)
+		return failure
+	}
+
+	return assign(0, bindings{})
+}
+
+// match selects the part of the input addressed by the element's index and
+// matches the element's value against it.
+func (e Element) match(input []json.RawMessage, b bindings, m mode, k continuation) error {
+	switch index := e.Index.(type) {
+	case Every:
+		return index.match(e.Value, input, b, m, k)
+
+	case Some:
+		return index.match(e.Value, input, b, m, k)
+
+	case Slice:
+		from, to, err := index.Bounds(b, len(input))
+		if err != nil {
+			return err
+		}
+
+		value, err := json.Marshal(input[from:to])
 		if err != nil {
-			return nil, fmt.Errorf("could not match index %s%d: %s", prefix, index, err)
+			return err
 		}
 
-		for k, v := range matched_bindings {
-			if _, k_exists := bCopy[k]; k_exists {
-				return nil, fmt.Errorf("binding for %s already exists and cannot be overwritten", k)
+		return within(fmt.Sprintf("could not match slice %s", index), func(k continuation) error {
+			return m.each(e.Value, value, b, k)
+		}, k)
+
+	default:
+		i, err := index.Index(b)
+		if err != nil {
+			return err
+		}
+
+		position := i
+		if position < 0 {
+			position += len(input)
+		}
+
+		if position < 0 || position >= len(input) {
+			if e.Optional {
+				return k(bindings{})
 			}
 
-			bCopy[k] = v
-			bNew[k] = v
+			return fmt.Errorf("array was not long enough to contain required index %s", describe(index, i))
 		}
+
+		return within(fmt.Sprintf("could not match index %s", describe(index, position)), func(k continuation) error {
+			return m.each(e.Value, input[position], b, k)
+		}, k)
+	}
+}
+
+// describe prints the position an index resolved to, prefixed by the index
+// itself when it was not written as that position.
+func describe(i Index, position int) string {
+	prefix := ""
+	if i.String() != fmt.Sprint(position) {
+		prefix = i.String() + " = "
 	}
 
-	return bNew, nil
+	return prefix + fmt.Sprint(position)
 }
 
 func (a Array) Validate(s set) error {
+	slices := []Slice{}
 	indices := set{}
+	required, requiredBy := 0, Number(0)
 	for _, e := range a.Elements {
+		if slice, ok := e.Index.(Slice); ok {
+			for _, other := range slices {
+				if slice.overlaps(other) {
+					return fmt.Errorf("slice %s overlaps slice %s", slice, other)
+				}
+			}
+
+			slices = append(slices, slice)
+		}
+
+		if n, ok := e.Index.(Number); ok {
+			i, err := n.Index(nil)
+			if err != nil {
+				return err
+			}
+
+			if i < 0 {
+				i = -i - 1
+			}
+
+			if !e.Optional && i >= required {
+				required, requiredBy = i+1, n
+			}
+		}
+
+		// searches may repeat, each looking for its own element
+		if _, ok := e.Index.(Some); ok {
+			continue
+		}
+
 		index := e.Index.String()
 		if _, exists := indices[index]; exists {
 			return fmt.Errorf("duplicate index %s", index)
@@ -91,7 +248,43 @@ func (a Array) Validate(s set) error {
 		indices[index] = true
 	}
 
+	if a.Length != nil {
+		if err := a.Length.validate(); err != nil {
+			return err
+		}
+
+		if a.Exact && !a.Length.admits(len(a.Elements)) {
+			return fmt.Errorf("length constraint %s conflicts with exact tuple of %d elements", a.Length, len(a.Elements))
+		}
+
+		if a.Length.Max != nil && *a.Length.Max < Number(required) {
+			return fmt.Errorf("length constraint %s conflicts with required index %s", a.Length, requiredBy)
+		}
+	}
+
 	for _, e := range a.Elements {
+		if every, ok := e.Index.(Every); ok {
+			if err := every.validate(e.Value, s); err != nil {
+				return fmt.Errorf("at index %s: %s", e.Index, err)
+			}
+
+			continue
+		}
+
+		if some, ok := e.Index.(Some); ok {
+			if err := some.validate(e.Value, s); err != nil {
+				return fmt.Errorf("at index %s: %s", e.Index, err)
+			}
+
+			continue
+		}
+
+		if index, ok := e.Index.(Validator); ok {
+			if err := index.Validate(s); err != nil {
+				return fmt.Errorf("at index %s: %s", e.Index, err)
+			}
+		}
+
 		value, ok := e.Value.(Validator)
 		if !ok {
 			continue
@@ -106,10 +299,24 @@ func (a Array) Validate(s set) error {
 }
 
 func (a Array) String() string {
-	s := "["
+	begin, end := "[", "]"
+	if a.Exact {
+		begin, end = "[|", "|]"
+	}
+
+	if a.Unordered {
+		begin += "&"
+	}
+
+	s := begin
 
 	for i, definition := range a.Elements {
-		s += "\n" + indent(definition.String())
+		entry := definition.String()
+		if a.Positional {
+			entry = definition.Value.String()
+		}
+
+		s += "\n" + indent(entry)
 
 		if i < len(a.Elements)-1 {
 			s += ","
@@ -118,7 +325,11 @@ func (a Array) String() string {
 		}
 	}
 
-	s += "]"
+	s += end
+
+	if a.Length != nil {
+		s += "#" + a.Length.String()
+	}
 
 	return s
 }
@@ -1,54 +1,39 @@
 package pattern
 
-import "fmt"
-
 type BoundLiteral struct {
 	Name  Binding
 	Value Value
 }
 
 func (b BoundLiteral) Match(s []byte, bOld bindings) (bindings, error) {
-	bNew := bindings{}
-
-	matched, err := b.Name.Match(s, bOld)
-	if err != nil {
-		return nil, err
-	}
+	return first(b, s, bOld)
+}
 
-	for k, v := range matched {
-		bNew[k] = v
-	}
+func (b BoundLiteral) Solve(s []byte, bOld bindings) ([]bindings, error) {
+	return all(b, s, bOld)
+}
 
-	matched, err = b.Value.Match(s, bOld)
+func (b BoundLiteral) each(s []byte, bOld bindings, m mode, k continuation) error {
+	named, err := b.Name.Match(s, bOld)
 	if err != nil {
-		return nil, err
-	}
-
-	for k, v := range matched {
-		bNew[k] = v
+		return err
 	}
 
-	return bNew, nil
+	return m.each(b.Value, s, bOld, func(matched bindings) error {
+		return k(merge(named, matched))
+	})
 }
 
 func (b BoundLiteral) Validate(s set) error {
-	ref := b.Name.String()
-	if s[ref] {
-		return fmt.Errorf("illegal self reference to %s", ref)
-	}
-	s[ref] = true
-
 	value, ok := b.Value.(Validator)
-	if !ok {
-		return nil
-	}
-
-	err := value.Validate(s)
-	if err != nil {
-		return err
+	if ok {
+		err := value.Validate(s)
+		if err != nil {
+			return err
+		}
 	}
 
-	return nil
+	return b.Name.Validate(s)
 }
 
 func (b BoundLiteral) String() string {
@@ -0,0 +1,64 @@
+package pattern
+
+import (
+	"fmt"
+	"math"
+)
+
+// Comparison matches numbers that compare to a bound with one of the
+// operators <, <=, > or >=. An absent optional reference accepts any number.
+type Comparison struct {
+	Operator string
+	Bound    Index
+}
+
+func (c Comparison) Match(s []byte, b bindings) (bindings, error) {
+	n, err := number(s)
+	if err != nil {
+		return nil, err
+	}
+
+	open := math.Inf(1)
+	if c.Operator == ">" || c.Operator == ">=" {
+		open = math.Inf(-1)
+	}
+
+	bound, err := limit(c.Bound, b, open)
+	if err != nil {
+		return nil, err
+	}
+
+	var ok bool
+	switch c.Operator {
+	case "<":
+		ok = n < bound
+	case "<=":
+		ok = n <= bound
+	case ">":
+		ok = n > bound
+	case ">=":
+		ok = n >= bound
+	default:
+		return nil, fmt.Errorf("unknown comparison operator %s", c.Operator)
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("expected value %s %s but found %s", c.Operator, Number(bound), s)
+	}
+
+	return bindings{}, nil
+}
+
+func (c Comparison) Validate(s set) error {
+	if bound, ok := c.Bound.(Validator); ok {
+		if err := bound.Validate(s); err != nil {
+			return fmt.Errorf("in comparison %s: %s", c, err)
+		}
+	}
+
+	return nil
+}
+
+func (c Comparison) String() string {
+	return c.Operator + " " + c.Bound.String()
+}
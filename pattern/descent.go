@@ -0,0 +1,133 @@
+package pattern
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Descent searches the input and every value nested within it for a node
+// matching its value, visiting nodes depth first with object keys in sorted
+// order. When Pointer is present it is bound to the JSON Pointer of
+// the node that matched.
+type Descent struct {
+	Value   Value
+	Pointer *Binding
+}
+
+func (d Descent) Match(s []byte, bOld bindings) (bindings, error) {
+	return first(d, s, bOld)
+}
+
+func (d Descent) Solve(s []byte, bOld bindings) ([]bindings, error) {
+	return all(d, s, bOld)
+}
+
+func (d Descent) each(s []byte, bOld bindings, m mode, k continuation) error {
+	failure := fmt.Errorf("no nested value matched %s", d.Value)
+
+	// walk reports whether the continuation accepted a solution from the node
+	// or any node nested within it
+	var walk func(pointer string, node []byte) (bool, error)
+	walk = func(pointer string, node []byte) (bool, error) {
+		node = bytes.TrimSpace(node)
+
+		var downstream error
+		err := m.each(d.Value, node, bOld, func(matched bindings) error {
+			bNew := merge(matched, nil)
+			if d.Pointer != nil {
+				bNew[string(*d.Pointer)] = pointer
+			}
+
+			downstream = k(bNew)
+			return downstream
+		})
+
+		if err == nil {
+			return true, nil
+		}
+
+		if err == downstream {
+			failure = err
+		}
+
+		if len(node) == 0 || (node[0] != '{' && node[0] != '[') {
+			return false, nil
+		}
+
+		if node[0] == '[' {
+			var elements []json.RawMessage
+			if err := json.Unmarshal(node, &elements); err != nil {
+				return false, err
+			}
+
+			for i, element := range elements {
+				accepted, err := walk(fmt.Sprintf("%s/%d", pointer, i), element)
+				if accepted || err != nil {
+					return accepted, err
+				}
+			}
+
+			return false, nil
+		}
+
+		var members map[string]json.RawMessage
+		if err := json.Unmarshal(node, &members); err != nil {
+			return false, err
+		}
+
+		keys := []string{}
+		for k := range members {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			accepted, err := walk(pointer+"/"+escapePointer(k), members[k])
+			if accepted || err != nil {
+				return accepted, err
+			}
+		}
+
+		return false, nil
+	}
+
+	accepted, err := walk("", s)
+	if err != nil {
+		return err
+	}
+
+	if accepted {
+		return nil
+	}
+
+	return failure
+}
+
+func escapePointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
+
+func (d Descent) Validate(s set) error {
+	if value, ok := d.Value.(Validator); ok {
+		if err := value.Validate(s); err != nil {
+			return err
+		}
+	}
+
+	if d.Pointer != nil {
+		return d.Pointer.Validate(s)
+	}
+
+	return nil
+}
+
+func (d Descent) String() string {
+	if d.Pointer != nil {
+		return ".." + d.Pointer.String() + " " + d.Value.String()
+	}
+
+	return ".." + d.Value.String()
+}
@@ -0,0 +1,118 @@
+package pattern
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Entries is the key of a field that matches every entry of an object whose
+// key matches the pattern, collecting each binding made by the key and value
+// into a list with one entry per key, in key order.
+type Entries struct {
+	// Pattern constrains and binds the keys, entries whose key does not match
+	// are skipped, a nil pattern accepts every key
+	Pattern Value
+
+	// Names bound by the key pattern and the field's value, so that empty
+	// objects still bind empty lists
+	Names []string
+}
+
+func (Entries) Key(_ bindings) (string, error) {
+	return "", fmt.Errorf("every entry quantifier does not address a single key")
+}
+
+// match matches the key pattern and value against each accepted entry in
+// turn, giving every combination of the ways they match to the continuation.
+func (e Entries) match(v Value, input map[string]json.RawMessage, b bindings, m mode, k continuation) error {
+	keys := e.keys(input, b)
+
+	var next func(int, []bindings) error
+	next = func(i int, combination []bindings) error {
+		if i == len(keys) {
+			return k(Every{Names: e.Names}.collect(combination))
+		}
+
+		key := keys[i]
+		return e.matchKey(key, b, m, func(named bindings) error {
+			return within(fmt.Sprintf("could not match entry %s", String(key)), func(k continuation) error {
+				return m.each(v, input[key], b, k)
+			}, func(matched bindings) error {
+				return next(i+1, append(combination[:i:i], merge(named, matched)))
+			})
+		})
+	}
+
+	return next(0, []bindings{})
+}
+
+// keys returns the input keys accepted by the pattern, in sorted order.
+func (e Entries) keys(input map[string]json.RawMessage, b bindings) []string {
+	keys := []string{}
+	for key := range input {
+		if e.accepts(key, b) {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+func (e Entries) accepts(key string, b bindings) bool {
+	if e.Pattern == nil {
+		return true
+	}
+
+	encoded, err := json.Marshal(key)
+	if err != nil {
+		return false
+	}
+
+	_, err = e.Pattern.Match(encoded, b)
+	return err == nil
+}
+
+func (e Entries) matchKey(key string, b bindings, m mode, k continuation) error {
+	if e.Pattern == nil {
+		return k(bindings{})
+	}
+
+	encoded, err := json.Marshal(key)
+	if err != nil {
+		return err
+	}
+
+	return m.each(e.Pattern, encoded, b, k)
+}
+
+func (e Entries) validate(v Value, s set) error {
+	sEntries := copySet(s)
+
+	if pattern, ok := e.Pattern.(Validator); ok {
+		if err := pattern.Validate(sEntries); err != nil {
+			return err
+		}
+	}
+
+	if value, ok := v.(Validator); ok {
+		if err := value.Validate(sEntries); err != nil {
+			return err
+		}
+	}
+
+	for _, k := range added(s, sEntries) {
+		s[k] = true
+	}
+
+	return nil
+}
+
+func (e Entries) String() string {
+	if e.Pattern == nil {
+		return "*"
+	}
+
+	return "*" + e.Pattern.String()
+}
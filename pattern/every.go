@@ -0,0 +1,110 @@
+package pattern
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Every is the index of an element that matches every element of an array,
+// collecting each binding made into a list with one entry per element.
+type Every struct {
+	// Names bound by the element's value, so that empty arrays still bind
+	// empty lists
+	Names []string
+}
+
+func (Every) Index(_ bindings) (int, error) {
+	return 0, fmt.Errorf("every element quantifier does not address a single index")
+}
+
+// match matches the value against each element in turn, giving every
+// combination of the ways they match to the continuation, in order.
+func (e Every) match(v Value, input []json.RawMessage, b bindings, m mode, k continuation) error {
+	var next func(int, []bindings) error
+	next = func(i int, combination []bindings) error {
+		if i == len(input) {
+			return k(e.collect(combination))
+		}
+
+		return within(fmt.Sprintf("could not match element %d", i), func(k continuation) error {
+			return m.each(v, input[i], b, k)
+		}, func(matched bindings) error {
+			return next(i+1, append(combination[:i:i], matched))
+		})
+	}
+
+	return next(0, []bindings{})
+}
+
+// collect gathers the bindings made by each element into lists, with an
+// entry for every element, null where that element did not make the binding.
+func (e Every) collect(matches []bindings) bindings {
+	names := append([]string{}, e.Names...)
+
+	for _, matched := range matches {
+		for k := range matched {
+			if !contains(names, k) {
+				names = append(names, k)
+			}
+		}
+	}
+
+	bNew := bindings{}
+	for _, k := range names {
+		list := []interface{}{}
+		for _, matched := range matches {
+			list = append(list, matched[k])
+		}
+
+		bNew[k] = list
+	}
+
+	return bNew
+}
+
+func (e Every) validate(v Value, s set) error {
+	value, ok := v.(Validator)
+	if !ok {
+		return nil
+	}
+
+	sEvery := copySet(s)
+	if err := value.Validate(sEvery); err != nil {
+		return err
+	}
+
+	for _, k := range added(s, sEvery) {
+		s[k] = true
+	}
+
+	return nil
+}
+
+func (Every) String() string {
+	return "*"
+}
+
+// binds lists the names bound by the values, in sorted order, by validating
+// them as though every name they refer to was already bound. Any error is
+// reported when the whole pattern is validated.
+func binds(values ...Value) []string {
+	before := set{anyName: true}
+	after := copySet(before)
+	for _, v := range values {
+		if value, ok := v.(Validator); ok {
+			value.Validate(after)
+		}
+	}
+
+	return added(before, after)
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
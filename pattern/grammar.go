@@ -7,15 +7,20 @@ import __yyfmt__ "fmt"
 
 //line grammar.y:2
 
-//line grammar.y:5
+import "regexp"
+
+//line grammar.y:7
 type yySymType struct {
-	yys int
-	num float64
-	str string
+	yys      int
+	num      float64
+	str      string
+	regex    *regexp.Regexp
+	template Template
 
 	pattern ValidatedPattern
 	obj     Object
 	arr     Array
+	length  Length
 	arrdef  Element
 	arrdefl []Element
 	objdef  Field
@@ -23,6 +28,7 @@ type yySymType struct {
 	opid    OptionalIdentifier
 	opidl   []OptionalIdentifier
 	val     Value
+	vall    []Value
 	ref     Reference
 	ind     Index
 	key     Key
@@ -32,9 +38,22 @@ type yySymType struct {
 const NULL = 57346
 const TRUE = 57347
 const FALSE = 57348
-const NUMBER = 57349
-const STRING = 57350
-const IDENTIFIER = 57351
+const LBRACE_BAR = 57349
+const BAR_RBRACE = 57350
+const LBRACKET_BAR = 57351
+const BAR_RBRACKET = 57352
+const ELLIPSIS = 57353
+const DOTDOT = 57354
+const LESS = 57355
+const LESS_EQUAL = 57356
+const GREATER = 57357
+const GREATER_EQUAL = 57358
+const NUMBER = 57359
+const STRING = 57360
+const IDENTIFIER = 57361
+const TYPE = 57362
+const REGEX = 57363
+const TEMPLATE = 57364
 
 var yyToknames = [...]string{
 	"$end",
@@ -43,19 +62,38 @@ var yyToknames = [...]string{
 	"NULL",
 	"TRUE",
 	"FALSE",
+	"LBRACE_BAR",
+	"BAR_RBRACE",
+	"LBRACKET_BAR",
+	"BAR_RBRACKET",
+	"ELLIPSIS",
+	"DOTDOT",
+	"LESS",
+	"LESS_EQUAL",
+	"GREATER",
+	"GREATER_EQUAL",
 	"NUMBER",
 	"STRING",
 	"IDENTIFIER",
+	"TYPE",
+	"REGEX",
+	"TEMPLATE",
+	"'#'",
 	"'['",
 	"']'",
+	"'&'",
 	"','",
 	"':'",
 	"'?'",
+	"'*'",
 	"'{'",
 	"'}'",
+	"'!'",
+	"'|'",
 	"'<'",
 	"'='",
 	"'>'",
+	"'_'",
 	"'.'",
 }
 
@@ -66,90 +104,170 @@ const yyErrCode = 2
 const yyInitialStackSize = 16
 
 //line yacctab:1
-var yyExca = [...]int8{
+var yyExca = [...]int16{
 	-1, 1,
 	1, -1,
 	-2, 0,
+	-1, 72,
+	28, 38,
+	29, 38,
+	-2, 60,
+	-1, 110,
+	28, 41,
+	29, 41,
+	-2, 60,
+	-1, 135,
+	28, 39,
+	29, 39,
+	-2, 68,
 }
 
 const yyPrivate = 57344
 
-const yyLast = 66
-
-var yyAct = [...]int8{
-	45, 25, 27, 29, 30, 31, 33, 32, 53, 4,
-	13, 50, 51, 46, 5, 8, 28, 29, 30, 31,
-	33, 32, 43, 4, 39, 52, 15, 21, 5, 41,
-	42, 20, 38, 24, 11, 4, 22, 23, 46, 47,
-	5, 40, 48, 18, 19, 37, 16, 17, 49, 10,
-	44, 15, 54, 6, 10, 34, 2, 35, 3, 36,
-	26, 14, 9, 12, 7, 1,
+const yyLast = 347
+
+var yyAct = [...]uint8{
+	14, 68, 2, 26, 67, 5, 56, 39, 38, 65,
+	80, 113, 81, 40, 41, 146, 114, 85, 61, 25,
+	114, 55, 47, 48, 49, 50, 45, 92, 59, 6,
+	85, 72, 60, 60, 127, 34, 82, 35, 33, 25,
+	58, 75, 32, 83, 79, 35, 55, 119, 107, 43,
+	44, 71, 70, 59, 59, 92, 89, 35, 3, 53,
+	97, 91, 134, 40, 41, 58, 58, 52, 93, 94,
+	35, 35, 90, 95, 110, 84, 103, 108, 131, 45,
+	36, 104, 105, 136, 124, 129, 112, 102, 51, 62,
+	115, 77, 78, 116, 60, 121, 122, 120, 123, 111,
+	102, 126, 106, 44, 128, 125, 130, 138, 132, 133,
+	135, 101, 99, 102, 100, 137, 102, 40, 41, 118,
+	142, 42, 88, 117, 140, 139, 141, 87, 143, 109,
+	98, 37, 57, 144, 69, 54, 145, 8, 9, 10,
+	29, 64, 31, 147, 4, 73, 21, 22, 23, 24,
+	25, 11, 86, 18, 12, 13, 27, 30, 63, 66,
+	15, 16, 71, 70, 28, 1, 19, 0, 7, 0,
+	0, 17, 8, 9, 10, 29, 0, 31, 74, 0,
+	20, 21, 22, 23, 24, 25, 11, 0, 18, 12,
+	13, 0, 30, 0, 76, 0, 0, 0, 0, 28,
+	0, 19, 0, 7, 0, 0, 17, 8, 9, 10,
+	29, 0, 31, 0, 0, 20, 21, 22, 23, 24,
+	25, 11, 0, 18, 12, 13, 0, 30, 0, 0,
+	0, 96, 0, 0, 28, 0, 19, 0, 7, 0,
+	0, 17, 8, 9, 10, 29, 0, 31, 0, 0,
+	20, 21, 22, 23, 24, 25, 11, 0, 18, 12,
+	13, 0, 30, 0, 0, 0, 0, 0, 0, 28,
+	0, 19, 0, 7, 0, 0, 17, 8, 9, 10,
+	29, 0, 31, 0, 0, 20, 21, 22, 23, 24,
+	25, 11, 0, 18, 12, 13, 0, 30, 0, 0,
+	0, 0, 0, 0, 28, 0, 19, 0, 46, 0,
+	0, 17, 8, 9, 10, 29, 0, 31, 0, 0,
+	20, 21, 22, 23, 24, 25, 11, 0, 18, 12,
+	13, 0, 30, 0, 0, 0, 0, 0, 0, 28,
+	0, 19, 0, 35, 0, 0, 17,
 }
 
 var yyPact = [...]int16{
-	25, -1000, -1000, -1000, 42, 18, -1000, 35, -1000, 30,
-	-1000, -1000, 15, -1000, 23, -1000, -1000, 47, -1, 32,
-	-1000, 43, -1, 28, -1000, -1000, 13, -1000, 4, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1, -1000, -1000,
-	-1, -1000, 29, 39, -8, -1000, 11, -1000, -1000, -11,
-	-1000, 29, -1000, -1000, -1000,
+	238, -1000, -1000, 8, 4, 308, -1000, 44, -1000, -1000,
+	-1000, -1000, -1000, -1000, 109, -1000, -1000, -1000, -1000, 308,
+	273, 2, 2, 2, 2, -1000, -1000, 65, 35, 10,
+	133, 168, 238, 238, -1000, 98, 98, -27, -1000, 7,
+	-1000, -1000, 2, -1000, -1000, 308, -6, -1000, -1000, -1000,
+	-1000, 110, -1000, 24, 45, -8, -1000, 40, 203, -1000,
+	-1000, -1000, 122, -1000, 87, 86, 238, -1000, -1000, 53,
+	74, 20, 117, 273, -1000, 89, 238, -1000, -1000, -17,
+	-1000, 98, -1000, -1000, -1000, 98, -1000, 111, 102, -1000,
+	36, -1000, -19, 238, 68, -1000, 238, 56, -1000, -1000,
+	22, -1000, 238, 60, 238, 50, 238, 238, 34, 2,
+	109, -1000, 73, 95, -1000, -1000, -21, 90, -1000, -8,
+	-1000, -1000, 238, -1000, 238, -1000, 108, 2, -1000, -1000,
+	-1000, 238, -1000, -1000, 238, -1000, -1000, -22, -1000, -1000,
+	-1000, -1000, 2, -1000, -1000, -1000, -1000, -1000,
 }
 
-var yyPgo = [...]int8{
-	0, 65, 57, 55, 2, 1, 15, 64, 10, 63,
-	62, 61, 60, 59, 0, 50,
+var yyPgo = [...]uint8{
+	0, 165, 161, 59, 160, 156, 152, 29, 1, 58,
+	144, 4, 141, 9, 6, 135, 134, 0, 132, 5,
+	3, 8, 131, 7,
 }
 
 var yyR1 = [...]int8{
-	0, 1, 1, 3, 3, 7, 7, 6, 6, 2,
-	2, 9, 9, 8, 8, 10, 11, 5, 5, 5,
-	4, 4, 4, 4, 4, 4, 4, 4, 12, 13,
-	15, 15, 14, 14,
+	0, 1, 4, 4, 6, 6, 6, 6, 5, 5,
+	5, 5, 5, 5, 5, 13, 13, 12, 12, 11,
+	11, 11, 11, 11, 2, 2, 2, 2, 3, 3,
+	3, 15, 15, 14, 14, 14, 14, 14, 16, 16,
+	16, 16, 17, 17, 18, 18, 8, 8, 10, 10,
+	9, 9, 9, 9, 7, 7, 7, 7, 7, 7,
+	7, 7, 7, 7, 7, 7, 7, 7, 7, 7,
+	7, 7, 7, 19, 20, 22, 22, 21, 21, 23,
+	23,
 }
 
 var yyR2 = [...]int8{
-	0, 1, 1, 2, 3, 1, 3, 3, 4, 2,
-	3, 1, 3, 3, 4, 1, 1, 1, 1, 2,
-	1, 1, 1, 1, 1, 1, 1, 1, 4, 3,
-	1, 3, 1, 2,
+	0, 1, 1, 3, 1, 3, 2, 2, 2, 3,
+	3, 2, 3, 4, 4, 1, 3, 1, 3, 3,
+	4, 3, 3, 4, 2, 3, 2, 3, 1, 2,
+	4, 1, 3, 3, 4, 2, 3, 4, 1, 3,
+	2, 2, 1, 1, 1, 1, 1, 1, 3, 3,
+	1, 1, 2, 6, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 1, 2, 2, 3, 3, 2,
+	2, 2, 2, 4, 3, 1, 3, 1, 2, 1,
+	1,
 }
 
 var yyChk = [...]int16{
-	-1000, -1, -3, -2, 10, 15, 11, -7, -6, -10,
-	7, 16, -9, -8, -11, 8, 11, 12, 13, 14,
-	16, 12, 13, 14, -6, -5, -12, -4, 17, 4,
-	5, 6, 8, 7, -3, -2, -13, 13, -8, -5,
-	13, -4, 17, 18, -15, -14, 9, -5, -5, 9,
-	19, 20, 14, 19, -14,
+	-1000, -1, -8, -9, -10, -19, -7, 35, 4, 5,
+	6, 18, 21, 22, -17, -4, -2, 38, 20, 33,
+	12, 13, 14, 15, 16, 17, -20, -5, 31, 7,
+	24, 9, 34, 34, -7, 35, 36, -22, -21, -23,
+	19, 20, 12, -7, -7, -19, 35, -17, -17, -17,
+	-17, 23, 32, -3, -15, 11, -14, -18, 30, 18,
+	-20, 8, -3, 25, -12, -13, 26, -11, -8, -16,
+	30, 29, -17, 12, 10, -13, 26, -9, -9, -23,
+	37, 39, 29, -17, -7, 36, -6, 17, 12, 32,
+	27, -19, 35, 28, 29, 33, 28, -8, 8, 25,
+	27, 25, 27, -13, 28, 29, 28, 28, -19, 12,
+	-17, 10, -13, 28, 37, -21, -23, 12, 17, 11,
+	-14, -8, 28, -8, 28, -11, -17, 12, -8, 25,
+	-8, 28, -8, -8, 28, -17, 10, 20, 17, -19,
+	-8, -8, 12, -17, -8, -8, 37, -17,
 }
 
 var yyDef = [...]int8{
-	0, -2, 1, 2, 0, 0, 3, 0, 5, 0,
-	15, 9, 0, 11, 0, 16, 4, 0, 0, 0,
-	10, 0, 0, 0, 6, 7, 17, 18, 0, 20,
-	21, 22, 23, 24, 25, 26, 27, 0, 12, 13,
-	0, 19, 0, 0, 0, 30, 32, 8, 14, 0,
-	29, 0, 33, 28, 31,
+	0, -2, 1, 46, 47, 50, 51, 0, 54, 55,
+	56, 57, 58, 59, 60, 61, 62, 63, 64, 0,
+	0, 0, 0, 0, 0, 42, 43, 2, 0, 0,
+	0, 0, 0, 0, 52, 0, 0, 0, 75, 77,
+	79, 80, 0, 65, 66, 0, 0, 69, 70, 71,
+	72, 0, 24, 0, 28, 0, 31, 0, 0, 44,
+	45, 26, 0, 8, 0, 0, 0, 17, 15, 0,
+	0, 0, -2, 0, 11, 0, 0, 48, 49, 0,
+	74, 0, 78, 68, 67, 0, 3, 4, 0, 25,
+	0, 29, 0, 0, 0, 35, 0, 0, 27, 9,
+	0, 10, 0, 0, 0, 0, 0, 0, 0, 40,
+	-2, 12, 0, 0, 73, 76, 0, 6, 7, 0,
+	32, 33, 0, 36, 0, 18, 38, 0, 16, 13,
+	19, 0, 21, 22, 0, -2, 14, 0, 5, 30,
+	34, 37, 40, 41, 20, 23, 53, 39,
 }
 
 var yyTok1 = [...]int8{
 	1, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
+	3, 3, 3, 33, 3, 23, 3, 3, 26, 3,
+	3, 3, 30, 3, 27, 3, 39, 3, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 3, 28, 3,
+	35, 36, 37, 29, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 3, 3, 3, 12, 3, 20, 3, 3, 3,
-	3, 3, 3, 3, 3, 3, 3, 3, 13, 3,
-	17, 18, 19, 14, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
+	3, 24, 3, 25, 3, 38, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 10, 3, 11, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 3, 3, 15, 3, 16,
+	3, 3, 3, 31, 34, 32,
 }
 
 var yyTok2 = [...]int8{
-	2, 3, 4, 5, 6, 7, 8, 9,
+	2, 3, 4, 5, 6, 7, 8, 9, 10, 11,
+	12, 13, 14, 15, 16, 17, 18, 19, 20, 21,
+	22,
 }
 
 var yyTok3 = [...]int8{
@@ -495,202 +613,495 @@ yydefault:
 
 	case 1:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line grammar.y:50
+//line grammar.y:63
 		{
-			yylex.(*lex).out = yyDollar[1].arr
+			yylex.(*lex).out = Root{yyDollar[1].val}
 		}
 	case 2:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line grammar.y:51
+//line grammar.y:66
 		{
-			yylex.(*lex).out = yyDollar[1].obj
+			yyVAL.arr = yyDollar[1].arr
 		}
 	case 3:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line grammar.y:67
+		{
+			length := yyDollar[3].length
+			yyVAL.arr = yyDollar[1].arr
+			yyVAL.arr.Length = &length
+		}
+	case 4:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line grammar.y:70
+		{
+			n := Number(yyDollar[1].num)
+			yyVAL.length = Length{Min: &n, Max: &n}
+		}
+	case 5:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line grammar.y:71
+		{
+			min, max := Number(yyDollar[1].num), Number(yyDollar[3].num)
+			yyVAL.length = Length{Min: &min, Max: &max}
+		}
+	case 6:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line grammar.y:54
+//line grammar.y:72
+		{
+			min := Number(yyDollar[1].num)
+			yyVAL.length = Length{Min: &min}
+		}
+	case 7:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line grammar.y:73
+		{
+			max := Number(yyDollar[2].num)
+			yyVAL.length = Length{Max: &max}
+		}
+	case 8:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line grammar.y:76
 		{
 			yyVAL.arr = Array{}
 		}
-	case 4:
+	case 9:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line grammar.y:55
+//line grammar.y:77
 		{
-			yyVAL.arr = Array{yyDollar[2].arrdefl}
+			yyVAL.arr = Array{Elements: yyDollar[2].arrdefl}
 		}
-	case 5:
+	case 10:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line grammar.y:78
+		{
+			yyVAL.arr = Array{Elements: yyDollar[2].arrdefl, Positional: true}
+		}
+	case 11:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line grammar.y:79
+		{
+			yyVAL.arr = Array{Positional: true, Exact: true}
+		}
+	case 12:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line grammar.y:80
+		{
+			yyVAL.arr = Array{Elements: yyDollar[2].arrdefl, Positional: true, Exact: true}
+		}
+	case 13:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line grammar.y:81
+		{
+			yyVAL.arr = Array{Elements: yyDollar[3].arrdefl, Positional: true, Unordered: true}
+		}
+	case 14:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line grammar.y:82
+		{
+			yyVAL.arr = Array{Elements: yyDollar[3].arrdefl, Positional: true, Unordered: true, Exact: true}
+		}
+	case 15:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line grammar.y:58
+//line grammar.y:85
+		{
+			yyVAL.arrdefl = []Element{{Index: Number(0), Value: yyDollar[1].val}}
+		}
+	case 16:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line grammar.y:86
+		{
+			yyVAL.arrdefl = append(yyDollar[1].arrdefl, Element{Index: Number(len(yyDollar[1].arrdefl)), Value: yyDollar[3].val})
+		}
+	case 17:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line grammar.y:89
 		{
 			yyVAL.arrdefl = []Element{yyDollar[1].arrdef}
 		}
-	case 6:
+	case 18:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line grammar.y:59
+//line grammar.y:90
 		{
 			yyVAL.arrdefl = append(yyDollar[1].arrdefl, yyDollar[3].arrdef)
 		}
-	case 7:
+	case 19:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line grammar.y:62
+//line grammar.y:93
 		{
 			yyVAL.arrdef = Element{Index: yyDollar[1].ind, Optional: false, Value: yyDollar[3].val}
 		}
-	case 8:
+	case 20:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line grammar.y:63
+//line grammar.y:94
 		{
 			yyVAL.arrdef = Element{Index: yyDollar[1].ind, Optional: true, Value: yyDollar[4].val}
 		}
-	case 9:
+	case 21:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line grammar.y:95
+		{
+			yyVAL.arrdef = Element{Index: Every{Names: binds(yyDollar[3].val)}, Value: yyDollar[3].val}
+		}
+	case 22:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line grammar.y:96
+		{
+			yyVAL.arrdef = Element{Index: Some{}, Value: yyDollar[3].val}
+		}
+	case 23:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line grammar.y:97
+		{
+			position := yyDollar[2].bnd
+			yyVAL.arrdef = Element{Index: Some{Position: &position}, Value: yyDollar[4].val}
+		}
+	case 24:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line grammar.y:66
+//line grammar.y:100
 		{
 			yyVAL.obj = Object{}
 		}
-	case 10:
+	case 25:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line grammar.y:67
+//line grammar.y:101
 		{
-			yyVAL.obj = Object{yyDollar[2].objdefl}
+			yyVAL.obj = yyDollar[2].obj
 		}
-	case 11:
+	case 26:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line grammar.y:102
+		{
+			yyVAL.obj = Object{Closed: true}
+		}
+	case 27:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line grammar.y:103
+		{
+			yyVAL.obj = yyDollar[2].obj
+			yyVAL.obj.Closed = true
+		}
+	case 28:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line grammar.y:70
+//line grammar.y:106
+		{
+			yyVAL.obj = Object{Fields: yyDollar[1].objdefl}
+		}
+	case 29:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line grammar.y:107
+		{
+			rest := yyDollar[2].bnd
+			yyVAL.obj = Object{Rest: &rest}
+		}
+	case 30:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line grammar.y:108
+		{
+			rest := yyDollar[4].bnd
+			yyVAL.obj = Object{Fields: yyDollar[1].objdefl, Rest: &rest}
+		}
+	case 31:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line grammar.y:111
 		{
 			yyVAL.objdefl = []Field{yyDollar[1].objdef}
 		}
-	case 12:
+	case 32:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line grammar.y:71
+//line grammar.y:112
 		{
 			yyVAL.objdefl = append(yyDollar[1].objdefl, yyDollar[3].objdef)
 		}
-	case 13:
+	case 33:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line grammar.y:74
+//line grammar.y:115
 		{
 			yyVAL.objdef = Field{Key: yyDollar[1].key, Optional: false, Value: yyDollar[3].val}
 		}
-	case 14:
+	case 34:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line grammar.y:75
+//line grammar.y:116
 		{
 			yyVAL.objdef = Field{Key: yyDollar[1].key, Optional: true, Value: yyDollar[4].val}
 		}
-	case 15:
+	case 35:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line grammar.y:117
+		{
+			yyVAL.objdef = Field{Key: yyDollar[1].key, Absent: true}
+		}
+	case 36:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line grammar.y:118
+		{
+			yyVAL.objdef = Field{Key: Entries{Names: binds(yyDollar[3].val)}, Value: yyDollar[3].val}
+		}
+	case 37:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line grammar.y:119
+		{
+			yyVAL.objdef = Field{Key: Entries{Pattern: yyDollar[2].val, Names: binds(yyDollar[2].val, yyDollar[4].val)}, Value: yyDollar[4].val}
+		}
+	case 38:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line grammar.y:78
+//line grammar.y:122
+		{
+			yyVAL.ind = yyDollar[1].ind
+		}
+	case 39:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line grammar.y:123
+		{
+			yyVAL.ind = Slice{From: yyDollar[1].ind, To: yyDollar[3].ind}
+		}
+	case 40:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line grammar.y:124
+		{
+			yyVAL.ind = Slice{From: yyDollar[1].ind}
+		}
+	case 41:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line grammar.y:125
+		{
+			yyVAL.ind = Slice{To: yyDollar[2].ind}
+		}
+	case 42:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line grammar.y:128
 		{
 			yyVAL.ind = Number(yyDollar[1].num)
 		}
-	case 16:
+	case 43:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line grammar.y:82
+//line grammar.y:129
+		{
+			yyVAL.ind = yyDollar[1].ref
+		}
+	case 44:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line grammar.y:132
 		{
 			yyVAL.key = String(yyDollar[1].str)
 		}
-	case 17:
+	case 45:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line grammar.y:86
+//line grammar.y:133
+		{
+			yyVAL.key = yyDollar[1].ref
+		}
+	case 46:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line grammar.y:136
+		{
+			yyVAL.val = yyDollar[1].val
+		}
+	case 47:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line grammar.y:137
+		{
+			yyVAL.val = Alternation(yyDollar[1].vall)
+		}
+	case 48:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line grammar.y:140
+		{
+			yyVAL.vall = []Value{yyDollar[1].val, yyDollar[3].val}
+		}
+	case 49:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line grammar.y:141
+		{
+			yyVAL.vall = append(yyDollar[1].vall, yyDollar[3].val)
+		}
+	case 50:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line grammar.y:144
 		{
 			yyVAL.val = yyDollar[1].bnd
 		}
-	case 18:
+	case 51:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line grammar.y:87
+//line grammar.y:145
 		{
 			yyVAL.val = yyDollar[1].val
 		}
-	case 19:
+	case 52:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line grammar.y:88
+//line grammar.y:146
 		{
 			yyVAL.val = BoundLiteral{Name: yyDollar[1].bnd, Value: yyDollar[2].val}
 		}
-	case 20:
+	case 53:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line grammar.y:147
+		{
+			yyVAL.val = BoundLiteral{Name: Binding(yyDollar[3].str), Value: Type(yyDollar[5].str)}
+		}
+	case 54:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line grammar.y:91
+//line grammar.y:150
 		{
 			yyVAL.val = Null{}
 		}
-	case 21:
+	case 55:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line grammar.y:92
+//line grammar.y:151
 		{
 			yyVAL.val = Boolean(true)
 		}
-	case 22:
+	case 56:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line grammar.y:93
+//line grammar.y:152
 		{
 			yyVAL.val = Boolean(false)
 		}
-	case 23:
+	case 57:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line grammar.y:94
+//line grammar.y:153
 		{
 			yyVAL.val = String(yyDollar[1].str)
 		}
-	case 24:
+	case 58:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line grammar.y:95
+//line grammar.y:154
 		{
-			yyVAL.val = Number(yyDollar[1].num)
+			yyVAL.val = Regex{yyDollar[1].regex}
 		}
-	case 25:
+	case 59:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line grammar.y:96
+//line grammar.y:155
+		{
+			yyVAL.val = yyDollar[1].template
+		}
+	case 60:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line grammar.y:156
+		{
+			yyVAL.val = yyDollar[1].ind.(Value)
+		}
+	case 61:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line grammar.y:157
 		{
 			yyVAL.val = yyDollar[1].arr
 		}
-	case 26:
+	case 62:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line grammar.y:97
+//line grammar.y:158
 		{
 			yyVAL.val = yyDollar[1].obj
 		}
-	case 27:
+	case 63:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line grammar.y:98
+//line grammar.y:159
 		{
-			yyVAL.val = yyDollar[1].ref
+			yyVAL.val = Wildcard{}
 		}
-	case 28:
+	case 64:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line grammar.y:160
+		{
+			yyVAL.val = Type(yyDollar[1].str)
+		}
+	case 65:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line grammar.y:161
+		{
+			yyVAL.val = Negation{yyDollar[2].val}
+		}
+	case 66:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line grammar.y:162
+		{
+			yyVAL.val = Descent{Value: yyDollar[2].val}
+		}
+	case 67:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line grammar.y:163
+		{
+			pointer := yyDollar[2].bnd
+			yyVAL.val = Descent{Value: yyDollar[3].val, Pointer: &pointer}
+		}
+	case 68:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line grammar.y:164
+		{
+			yyVAL.val = Range{From: yyDollar[1].ind, To: yyDollar[3].ind}
+		}
+	case 69:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line grammar.y:165
+		{
+			yyVAL.val = Comparison{Operator: "<", Bound: yyDollar[2].ind}
+		}
+	case 70:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line grammar.y:166
+		{
+			yyVAL.val = Comparison{Operator: "<=", Bound: yyDollar[2].ind}
+		}
+	case 71:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line grammar.y:167
+		{
+			yyVAL.val = Comparison{Operator: ">", Bound: yyDollar[2].ind}
+		}
+	case 72:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line grammar.y:168
+		{
+			yyVAL.val = Comparison{Operator: ">=", Bound: yyDollar[2].ind}
+		}
+	case 73:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line grammar.y:101
+//line grammar.y:171
 		{
 			yyVAL.bnd = Binding(yyDollar[3].str)
 		}
-	case 29:
+	case 74:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line grammar.y:104
+//line grammar.y:174
 		{
 			yyVAL.ref = Reference(yyDollar[2].opidl)
 		}
-	case 30:
+	case 75:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line grammar.y:107
+//line grammar.y:177
 		{
 			yyVAL.opidl = []OptionalIdentifier{yyDollar[1].opid}
 		}
-	case 31:
+	case 76:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line grammar.y:108
+//line grammar.y:178
 		{
 			yyVAL.opidl = append(yyDollar[1].opidl, yyDollar[3].opid)
 		}
-	case 32:
+	case 77:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line grammar.y:112
+//line grammar.y:182
 		{
 			yyVAL.opid = OptionalIdentifier{Identifier: Identifier(yyDollar[1].str), Optional: false}
 		}
-	case 33:
+	case 78:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line grammar.y:113
+//line grammar.y:183
 		{
 			yyVAL.opid = OptionalIdentifier{Identifier: Identifier(yyDollar[1].str), Optional: true}
 		}
+	case 79:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line grammar.y:187
+		{
+			yyVAL.str = yyDollar[1].str
+		}
+	case 80:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line grammar.y:188
+		{
+			yyVAL.str = yyDollar[1].str
+		}
 	}
 	goto yystack /* stack new state and value */
 }
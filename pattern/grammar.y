@@ -1,14 +1,19 @@
 %{
 package pattern
+
+import "regexp"
 %}
 
 %union{
     num     float64
     str     string
+    regex   *regexp.Regexp
+    template Template
 
     pattern ValidatedPattern
     obj Object
     arr Array
+    length Length
     arrdef  Element
     arrdefl []Element
     objdef  Field
@@ -16,6 +21,7 @@ package pattern
     opid OptionalIdentifier
     opidl []OptionalIdentifier    
     val Value    
+    vall []Value
     ref Reference
     ind Index
     key Key
@@ -23,23 +29,30 @@ package pattern
 }
 
 %token NULL TRUE FALSE
+%token LBRACE_BAR BAR_RBRACE LBRACKET_BAR BAR_RBRACKET ELLIPSIS DOTDOT
+%token LESS LESS_EQUAL GREATER GREATER_EQUAL
 %token <num> NUMBER
-%token <str> STRING IDENTIFIER
+%token <str> STRING IDENTIFIER TYPE
+%token <regex> REGEX
+%token <template> TEMPLATE
 
 %type <pattern> pattern
-%type <obj> object
-%type <arr> array
-%type <val> value binding_or_value 
+%type <obj> object object_body
+%type <arr> array array_body
+%type <length> length
+%type <val> value binding_or_value alternative
+%type <vall> alternative_list
 %type <arrdef> array_definition
-%type <arrdefl> array_definition_list
+%type <arrdefl> array_definition_list tuple
 %type <objdef> object_definition
 %type <objdefl> object_definition_list
-%type <ind> index
+%type <ind> index bound
 %type <key> key
 %type <bnd> binding
 %type <ref> reference
 %type <opid> optional_identifier
 %type <opidl> optional_identifier_list
+%type <str> name
 
 
 %start pattern
@@ -47,12 +60,30 @@ package pattern
 %%
 
 pattern
-    : array     { yylex.(*lex).out = $1 }
-    | object    { yylex.(*lex).out = $1 }
+    : binding_or_value  { yylex.(*lex).out = Root{$1} }
 
 array
-    : '[' ']'                       { $$ = Array{} }
-    | '[' array_definition_list ']' { $$ = Array{$2} }
+    : array_body                { $$ = $1 }
+    | array_body '#' length     { length := $3; $$ = $1; $$.Length = &length }
+
+length
+    : NUMBER                { n := Number($1); $$ = Length{Min: &n, Max: &n} }
+    | NUMBER DOTDOT NUMBER  { min, max := Number($1), Number($3); $$ = Length{Min: &min, Max: &max} }
+    | NUMBER DOTDOT         { min := Number($1); $$ = Length{Min: &min} }
+    | DOTDOT NUMBER         { max := Number($2); $$ = Length{Max: &max} }
+
+array_body
+    : '[' ']'                           { $$ = Array{} }
+    | '[' array_definition_list ']'     { $$ = Array{Elements: $2} }
+    | '[' tuple ']'                     { $$ = Array{Elements: $2, Positional: true} }
+    | LBRACKET_BAR BAR_RBRACKET         { $$ = Array{Positional: true, Exact: true} }
+    | LBRACKET_BAR tuple BAR_RBRACKET   { $$ = Array{Elements: $2, Positional: true, Exact: true} }
+    | '[' '&' tuple ']'                     { $$ = Array{Elements: $3, Positional: true, Unordered: true} }
+    | LBRACKET_BAR '&' tuple BAR_RBRACKET   { $$ = Array{Elements: $3, Positional: true, Unordered: true, Exact: true} }
+
+tuple
+    : binding_or_value              { $$ = []Element{{Index: Number(0), Value: $1}} }
+    | tuple ',' binding_or_value    { $$ = append($1, Element{Index: Number(len($1)), Value: $3}) }
 
 array_definition_list
     : array_definition                              { $$ = []Element{$1} }
@@ -61,10 +92,20 @@ array_definition_list
 array_definition
     : index ':' binding_or_value      { $$ = Element{Index: $1, Optional: false, Value: $3} }
     | index '?' ':' binding_or_value  { $$ = Element{Index: $1, Optional: true, Value: $4} }
+    | '*' ':' binding_or_value        { $$ = Element{Index: Every{Names: binds($3)}, Value: $3} }
+    | '?' ':' binding_or_value          { $$ = Element{Index: Some{}, Value: $3} }
+    | '?' binding ':' binding_or_value  { position := $2; $$ = Element{Index: Some{Position: &position}, Value: $4} }
 
 object
     : '{' '}'                           { $$ = Object{} }
-    | '{' object_definition_list '}'    { $$ = Object{$2} }
+    | '{' object_body '}'               { $$ = $2 }
+    | LBRACE_BAR BAR_RBRACE             { $$ = Object{Closed: true} }
+    | LBRACE_BAR object_body BAR_RBRACE { $$ = $2; $$.Closed = true }
+
+object_body
+    : object_definition_list                        { $$ = Object{Fields: $1} }
+    | ELLIPSIS binding                              { rest := $2; $$ = Object{Rest: &rest} }
+    | object_definition_list ',' ELLIPSIS binding   { rest := $4; $$ = Object{Fields: $1, Rest: &rest} }
 
 object_definition_list
     : object_definition                             { $$ = []Field{$1} }
@@ -73,32 +114,61 @@ object_definition_list
 object_definition
     : key ':' binding_or_value       { $$ = Field{Key: $1, Optional: false, Value: $3} }
     | key '?' ':' binding_or_value   { $$ = Field{Key: $1, Optional: true, Value: $4} }
+    | key '!'                        { $$ = Field{Key: $1, Absent: true} }
+    | '*' ':' binding_or_value                      { $$ = Field{Key: Entries{Names: binds($3)}, Value: $3} }
+    | '*' binding_or_value ':' binding_or_value     { $$ = Field{Key: Entries{Pattern: $2, Names: binds($2, $4)}, Value: $4} }
+
+index
+    : bound                 { $$ = $1 }
+    | bound DOTDOT bound    { $$ = Slice{From: $1, To: $3} }
+    | bound DOTDOT          { $$ = Slice{From: $1} }
+    | DOTDOT bound          { $$ = Slice{To: $2} }
 
-index  
+bound
     : NUMBER    { $$ = Number($1) }
-    /* | reference { $$ = $1 } */
+    | reference { $$ = $1 }
 
 key
     : STRING    { $$ = String($1) }
-    /* | reference { $$ = $1 } */
+    | reference { $$ = $1 }
 
 binding_or_value
+    : alternative       { $$ = $1 }
+    | alternative_list  { $$ = Alternation($1) }
+
+alternative_list
+    : alternative '|' alternative       { $$ = []Value{$1, $3} }
+    | alternative_list '|' alternative  { $$ = append($1, $3) }
+
+alternative
     : binding         { $$ = $1 }
     | value           { $$ = $1 }
     | binding value   { $$ = BoundLiteral{Name: $1, Value: $2} }
-    
+    | '<' '=' name ':' TYPE '>'         { $$ = BoundLiteral{Name: Binding($3), Value: Type($5)} }
+
 value
     : NULL      { $$ = Null{} }
     | TRUE      { $$ = Boolean(true) }
     | FALSE     { $$ = Boolean(false) }
     | STRING    { $$ = String($1) }
-    | NUMBER    { $$ = Number($1) }
+    | REGEX     { $$ = Regex{$1} }
+    | TEMPLATE  { $$ = $1 }
+    | bound     { $$ = $1.(Value) }
     | array     { $$ = $1 }
     | object    { $$ = $1 }
-    | reference { $$ = $1 }
+    | '_'       { $$ = Wildcard{} }
+    | TYPE      { $$ = Type($1) }
+    | '!' value { $$ = Negation{$2} }
+    | DOTDOT value          { $$ = Descent{Value: $2} }
+    | DOTDOT binding value  { pointer := $2; $$ = Descent{Value: $3, Pointer: &pointer} }
+    | bound DOTDOT bound    { $$ = Range{From: $1, To: $3} }
+    | LESS bound            { $$ = Comparison{Operator: "<", Bound: $2} }
+    | LESS_EQUAL bound      { $$ = Comparison{Operator: "<=", Bound: $2} }
+    | GREATER bound         { $$ = Comparison{Operator: ">", Bound: $2} }
+    | GREATER_EQUAL bound   { $$ = Comparison{Operator: ">=", Bound: $2} }
 
 binding
-    : '<' '=' IDENTIFIER '>'  { $$ = Binding($3) }
+    : '<' '=' name '>'  { $$ = Binding($3) }
 
 reference
     : '<' optional_identifier_list '>'  { $$ = Reference($2) }
@@ -109,5 +179,10 @@ optional_identifier_list
 
 
 optional_identifier
-    : IDENTIFIER        { $$ = OptionalIdentifier{Identifier: Identifier($1), Optional: false} }
-    | IDENTIFIER '?'    { $$ = OptionalIdentifier{Identifier: Identifier($1), Optional: true} }
\ No newline at end of file
+    : name          { $$ = OptionalIdentifier{Identifier: Identifier($1), Optional: false} }
+    | name '?'      { $$ = OptionalIdentifier{Identifier: Identifier($1), Optional: true} }
+
+// type names are only reserved where a value is expected
+name
+    : IDENTIFIER    { $$ = $1 }
+    | TYPE          { $$ = $1 }
\ No newline at end of file
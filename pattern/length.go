@@ -0,0 +1,75 @@
+package pattern
+
+import "fmt"
+
+// Length constrains the number of elements in an array to lie between Min
+// and Max inclusive, either of which may be omitted to leave that side open.
+type Length struct {
+	Min, Max *Number
+}
+
+func (l Length) check(n int) error {
+	if l.admits(n) {
+		return nil
+	}
+
+	switch {
+	case l.Min != nil && l.Max != nil && *l.Min == *l.Max:
+		return fmt.Errorf("expected array of length %s but found length %d", l.Min, n)
+	case l.Max == nil:
+		return fmt.Errorf("expected array of at least %s elements but found length %d", l.Min, n)
+	case l.Min == nil:
+		return fmt.Errorf("expected array of at most %s elements but found length %d", l.Max, n)
+	default:
+		return fmt.Errorf("expected array length in [%s, %s] but found length %d", l.Min, l.Max, n)
+	}
+}
+
+func (l Length) admits(n int) bool {
+	if l.Min != nil && Number(n) < *l.Min {
+		return false
+	}
+
+	if l.Max != nil && Number(n) > *l.Max {
+		return false
+	}
+
+	return true
+}
+
+func (l Length) validate() error {
+	for _, bound := range []*Number{l.Min, l.Max} {
+		if bound == nil {
+			continue
+		}
+
+		if n, err := bound.Index(nil); err != nil || n < 0 {
+			return fmt.Errorf("length %s must be a non-negative integer", bound)
+		}
+	}
+
+	if l.Min != nil && l.Max != nil && *l.Min > *l.Max {
+		return fmt.Errorf("length constraint %s has a minimum greater than its maximum", l)
+	}
+
+	return nil
+}
+
+func (l Length) String() string {
+	if l.Min != nil && l.Max != nil && *l.Min == *l.Max {
+		return l.Min.String()
+	}
+
+	s := ""
+	if l.Min != nil {
+		s += l.Min.String()
+	}
+
+	s += ".."
+
+	if l.Max != nil {
+		s += l.Max.String()
+	}
+
+	return s
+}
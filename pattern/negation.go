@@ -0,0 +1,42 @@
+package pattern
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Negation struct {
+	Value Value
+}
+
+func (n Negation) Match(s []byte, bOld bindings) (bindings, error) {
+	_, err := n.Value.Match(s, bOld)
+	if err == nil {
+		return nil, fmt.Errorf("value '%s' matched forbidden pattern %s", s, n.Value)
+	}
+
+	return bindings{}, nil
+}
+
+func (n Negation) Validate(s set) error {
+	value, ok := n.Value.(Validator)
+	if !ok {
+		return nil
+	}
+
+	sNeg := copySet(s)
+	if err := value.Validate(sNeg); err != nil {
+		return err
+	}
+
+	names := added(s, sNeg)
+	if len(names) > 0 {
+		return fmt.Errorf("negated pattern %s cannot bind %s", n.Value, strings.Join(names, ", "))
+	}
+
+	return nil
+}
+
+func (n Negation) String() string {
+	return "!" + n.Value.String()
+}
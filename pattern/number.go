@@ -2,12 +2,17 @@ package pattern
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 )
 
 type Number float64
 
-func (i Number) Index() (int, error) {
+func (i Number) Index(_ bindings) (int, error) {
+	if math.Trunc(float64(i)) != float64(i) {
+		return 0, fmt.Errorf("index %s is not an integer", i)
+	}
+
 	return int(i), nil
 }
 
@@ -3,20 +3,31 @@ package pattern
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
 type Object struct {
 	Fields []Field
+
+	// Closed objects reject any input key that is not declared by a field
+	Closed bool
+
+	// Rest, when present, binds an object of every input field not declared
+	// by a field
+	Rest *Binding
 }
 
 type Field struct {
 	Key      Key
 	Value    Value
 	Optional bool
+
+	// Absent fields have no value and require that the key is missing
+	Absent bool
 }
 
 type Key interface {
-	Key() (string, error)
+	Key(bindings) (string, error)
 	String() string
 }
 
@@ -31,6 +42,20 @@ func (o Object) Validate(s set) error {
 	}
 
 	for _, f := range o.Fields {
+		if entries, ok := f.Key.(Entries); ok {
+			if err := entries.validate(f.Value, s); err != nil {
+				return fmt.Errorf("at key %s: %s", f.Key, err)
+			}
+
+			continue
+		}
+
+		if key, ok := f.Key.(Validator); ok {
+			if err := key.Validate(s); err != nil {
+				return fmt.Errorf("at key %s: %s", f.Key, err)
+			}
+		}
+
 		value, ok := f.Value.(Validator)
 		if !ok {
 			continue
@@ -41,6 +66,12 @@ func (o Object) Validate(s set) error {
 		}
 	}
 
+	if o.Rest != nil {
+		if err := o.Rest.Validate(s); err != nil {
+			return fmt.Errorf("at rest: %s", err)
+		}
+	}
+
 	return nil
 }
 
@@ -48,12 +79,24 @@ func (o Object) Interpret(s string) (bindings, error) {
 	return o.Match([]byte(s), bindings{})
 }
 
-func (o Object) Match(s []byte, bOld bindings) (bindings, error) {
-	bCopy := bindings{}
-	for k, v := range bOld {
-		bCopy[k] = v
+func (o Object) InterpretAll(s string) ([]bindings, error) {
+	solutions, err := o.Solve([]byte(s), bindings{})
+	if err != nil {
+		return nil, err
 	}
 
+	return distinct(solutions), nil
+}
+
+func (o Object) Match(s []byte, bOld bindings) (bindings, error) {
+	return first(o, s, bOld)
+}
+
+func (o Object) Solve(s []byte, bOld bindings) ([]bindings, error) {
+	return all(o, s, bOld)
+}
+
+func (o Object) each(s []byte, bOld bindings, m mode, k continuation) error {
 	var input map[string]json.RawMessage
 	err := json.Unmarshal(s, &input)
 	if err != nil {
@@ -62,67 +105,157 @@ func (o Object) Match(s []byte, bOld bindings) (bindings, error) {
 			input = "'" + string(s) + "'"
 		}
 
-		return nil, fmt.Errorf("%s could not be interpreted as an object", input)
+		return fmt.Errorf("%s could not be interpreted as an object", input)
 	}
 
-	bNew := bindings{}
-	for _, definition := range o.Fields {
-		key, err := definition.Key.Key()
-		if err != nil {
-			return nil, err
+	return search(bOld, len(o.Fields)+1, func(i int, b bindings, m mode, k continuation) error {
+		if i == len(o.Fields) {
+			rest, err := o.remainder(input, b)
+			if err != nil {
+				return err
+			}
+
+			return k(rest)
 		}
 
-		prefix := ""
-		if definition.Key.String() != key {
-			prefix = definition.Key.String() + " = "
+		return o.Fields[i].match(input, b, m, k)
+	}, m, k)
+}
+
+func (f Field) match(input map[string]json.RawMessage, b bindings, m mode, k continuation) error {
+	if entries, ok := f.Key.(Entries); ok {
+		return entries.match(f.Value, input, b, m, k)
+	}
+
+	key, err := f.Key.Key(b)
+	if err != nil {
+		return err
+	}
+
+	prefix := ""
+	if f.Key.String() != String(key).String() {
+		prefix = f.Key.String() + " = "
+	}
+
+	value, key_exists := input[key]
+	if f.Absent {
+		if key_exists {
+			return fmt.Errorf("object contained forbidden field %s%s", prefix, String(key))
 		}
 
-		value, key_exists := input[key]
-		if !key_exists {
-			if definition.Optional {
-				continue
+		return k(bindings{})
+	}
+
+	if !key_exists {
+		if f.Optional {
+			return k(bindings{})
+		}
+
+		return fmt.Errorf("object did not contain required field %s%s", prefix, String(key))
+	}
+
+	return within(fmt.Sprintf("could not match field %s%s", prefix, String(key)), func(k continuation) error {
+		return m.each(f.Value, value, b, k)
+	}, k)
+}
+
+// remainder checks the input fields that were not declared by any field,
+// rejecting them from closed objects and binding them to the rest binding.
+func (o Object) remainder(input map[string]json.RawMessage, b bindings) (bindings, error) {
+	declared := set{}
+	for _, f := range o.Fields {
+		if entries, ok := f.Key.(Entries); ok {
+			for _, key := range entries.keys(input, b) {
+				declared[key] = true
 			}
 
-			return nil, fmt.Errorf("object did not contain required field %s\"%s\"", prefix, key)
+			continue
 		}
 
-		matched, err := definition.Value.Match(value, bCopy)
+		key, err := f.Key.Key(b)
 		if err != nil {
-			return nil, fmt.Errorf("could not match field %s\"%s\": %s", prefix, key, err)
+			return nil, err
+		}
+
+		declared[key] = true
+	}
+
+	undeclared := added(declared, inputKeys(input))
+
+	if o.Closed && len(undeclared) > 0 {
+		keys := []string{}
+		for _, k := range undeclared {
+			keys = append(keys, String(k).String())
 		}
 
-		for k, v := range matched {
-			if _, k_exists := bCopy[k]; k_exists {
-				return nil, fmt.Errorf("binding for %s already exists and cannot be overwritten", k)
+		return nil, fmt.Errorf("closed object contained unexpected keys %s", strings.Join(keys, ", "))
+	}
+
+	bNew := bindings{}
+	if o.Rest != nil {
+		rest := map[string]interface{}{}
+		for _, k := range undeclared {
+			var v interface{}
+			err := json.Unmarshal(input[k], &v)
+			if err != nil {
+				return nil, err
 			}
 
-			bCopy[k] = v
-			bNew[k] = v
+			rest[k] = v
 		}
+
+		bNew[string(*o.Rest)] = rest
 	}
 
 	return bNew, nil
 }
 
+func inputKeys(input map[string]json.RawMessage) set {
+	keys := set{}
+	for k := range input {
+		keys[k] = true
+	}
+
+	return keys
+}
+
 func (o Object) String() string {
-	s := "{"
+	begin, end := "{", "}"
+	if o.Closed {
+		begin, end = "{|", "|}"
+	}
 
-	for i, definition := range o.Fields {
-		s += "\n" + indent(definition.String())
+	entries := []string{}
+	for _, definition := range o.Fields {
+		entries = append(entries, definition.String())
+	}
+
+	if o.Rest != nil {
+		entries = append(entries, "..."+o.Rest.String())
+	}
 
-		if i < len(o.Fields)-1 {
+	s := begin
+
+	for i, entry := range entries {
+		s += "\n" + indent(entry)
+
+		if i < len(entries)-1 {
 			s += ","
 		} else {
 			s += "\n"
 		}
 	}
 
-	s += "}"
+	s += end
 
 	return s
 }
 
 func (f Field) String() string {
+	if f.Absent {
+		return f.Key.String() + "!"
+	}
+
 	op := ""
 	if f.Optional {
 		op = "?"
@@ -2,9 +2,11 @@ package pattern
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 	"unicode"
+	"unicode/utf16"
 )
 
 //go:generate go run golang.org/x/tools/cmd/goyacc@v0.2.0 -o grammar.go grammar.y
@@ -31,10 +33,27 @@ func Parse(s string) (ValidatedPattern, error) {
 
 const EOF = 0
 
+var keywords = map[string]int{
+	"null":  NULL,
+	"true":  TRUE,
+	"false": FALSE,
+
+	string(StringType):  TYPE,
+	string(NumberType):  TYPE,
+	string(IntegerType): TYPE,
+	string(BooleanType): TYPE,
+	string(ObjectType):  TYPE,
+	string(ArrayType):   TYPE,
+}
+
 type lex struct {
 	input []rune
 	i     int
 
+	// ref is set between angle brackets, where numeric path segments such
+	// as the 0 in <list.0> are identifiers rather than numbers
+	ref bool
+
 	out ValidatedPattern
 	err error
 }
@@ -53,25 +72,76 @@ func (l *lex) Lex(lval *yySymType) int {
 	}
 
 	switch l.next() {
-	case '[', ']', '{', '}', ':', ',', '<', '>', '=', '?', EOF:
+	case '<':
+		// comparisons are set apart from bindings and references by the
+		// bound that follows the operator, as in <10, <= -1 or < <limit>
+		if l.at(1) == '=' && l.bound(2) {
+			l.i += 2
+			return LESS_EQUAL
+		}
+		if l.bound(1) {
+			l.take()
+			return LESS
+		}
+		l.ref = true
+		return int(l.take())
+	case '>':
+		if l.ref {
+			l.ref = false
+			return int(l.take())
+		}
+		if l.match(">=") {
+			return GREATER_EQUAL
+		}
+		l.take()
+		return GREATER
+	case '{':
+		if l.match("{|") {
+			return LBRACE_BAR
+		}
+		return int(l.take())
+	case '[':
+		if l.match("[|") {
+			return LBRACKET_BAR
+		}
+		return int(l.take())
+	case '|':
+		if l.match("|}") {
+			return BAR_RBRACE
+		}
+		if l.match("|]") {
+			return BAR_RBRACKET
+		}
+		return int(l.take())
+	case '.':
+		if l.match("...") {
+			return ELLIPSIS
+		}
+		if l.match("..") {
+			return DOTDOT
+		}
+		return int(l.take())
+	case ']', '}', ':', ',', '=', '?', '_', '!', '#', '*', '&', EOF:
 		return int(l.take())
 	case '0', '9', '8', '7', '6', '5', '4', '3', '2', '1':
+		if l.ref {
+			return l.identifier(lval)
+		}
+		return l.num(lval)
+	case '-':
 		return l.num(lval)
 	case '"':
-		return l.str(lval)
-	default:
-		if l.match("true") {
-			return TRUE
+		return l.str(lval, false)
+	case '$':
+		if l.at(1) != '"' {
+			l.Error("expected string after $ to begin a template")
+			return yyErrCode
 		}
-
-		if l.match("false") {
-			return FALSE
-		}
-
-		if l.match("null") {
-			return NULL
-		}
-
+		l.take()
+		return l.str(lval, true)
+	case '/':
+		return l.regex(lval)
+	default:
 		if unicode.IsLetter(l.next()) {
 			return l.identifier(lval)
 		}
@@ -89,6 +159,17 @@ func (l *lex) at(i int) rune {
 	return EOF
 }
 
+// bound reports whether a number or reference starts at the given offset,
+// after any spaces.
+func (l *lex) bound(i int) bool {
+	for unicode.IsSpace(l.at(i)) {
+		i++
+	}
+
+	c := l.at(i)
+	return digit(c) || c == '-' || c == '<'
+}
+
 func (l *lex) next() rune {
 	return l.at(0)
 }
@@ -116,13 +197,43 @@ func (l *lex) match(s string) bool {
 
 func (l *lex) num(lval *yySymType) int {
 	var s strings.Builder
-	s.WriteRune(l.take())
 
-	for unicode.IsNumber(l.next()) {
+	if l.next() == '-' {
+		s.WriteRune(l.take())
+	}
+
+	if l.next() == '0' {
+		s.WriteRune(l.take())
+
+		if digit(l.next()) {
+			l.Error(fmt.Sprintf("malformed number %s: leading zeros are not allowed", s.String()+string(l.next())))
+			return yyErrCode
+		}
+	} else if !l.digits(&s) {
+		return l.malformed(s.String(), "expected digit")
+	}
+
+	if l.next() == '.' && l.at(1) != '.' {
 		s.WriteRune(l.take())
+
+		if !l.digits(&s) {
+			return l.malformed(s.String(), "expected digit after decimal point")
+		}
 	}
 
-	if unicode.IsLetter(l.next()) {
+	if l.next() == 'e' || l.next() == 'E' {
+		s.WriteRune(l.take())
+
+		if l.next() == '+' || l.next() == '-' {
+			s.WriteRune(l.take())
+		}
+
+		if !l.digits(&s) {
+			return l.malformed(s.String(), "expected digit in exponent")
+		}
+	}
+
+	if unicode.IsLetter(l.next()) || unicode.IsNumber(l.next()) {
 		l.Error(fmt.Sprintf("unexpected character %c in number", l.next()))
 		return yyErrCode
 	}
@@ -137,12 +248,70 @@ func (l *lex) num(lval *yySymType) int {
 	return NUMBER
 }
 
-func (l *lex) str(lval *yySymType) int {
+func (l *lex) digits(s *strings.Builder) bool {
+	if !digit(l.next()) {
+		return false
+	}
+
+	for digit(l.next()) {
+		s.WriteRune(l.take())
+	}
+
+	return true
+}
+
+func (l *lex) malformed(s, reason string) int {
+	found := "EOF"
+	if l.next() != EOF {
+		found = fmt.Sprintf("%c", l.next())
+	}
+
+	l.Error(fmt.Sprintf("malformed number %s: %s but found %s", s, reason, found))
+	return yyErrCode
+}
+
+func digit(r rune) bool {
+	return '0' <= r && r <= '9'
+}
+
+// str reads a string literal, or a template when it was prefixed by $, in
+// which placeholders are read as bindings and references and \< stands for
+// a literal <.
+func (l *lex) str(lval *yySymType, template bool) int {
 	l.take()
 	var s strings.Builder
+	segments := Template{}
 
 	for l.next() != '"' && l.next() != EOF {
-		s.WriteRune(l.take())
+		if value, n := placeholder(l.input[l.i:]); template && n > 0 {
+			if s.Len() > 0 {
+				segments = append(segments, String(s.String()))
+				s.Reset()
+			}
+
+			segments = append(segments, value)
+			l.i += n
+			continue
+		}
+
+		c := l.take()
+
+		switch {
+		case c == '\\' && template && l.next() == '<':
+			s.WriteRune(l.take())
+
+		case c == '\\':
+			if !l.escape(&s) {
+				return yyErrCode
+			}
+
+		case c < ' ':
+			l.Error(fmt.Sprintf("control character %U must be escaped in string", c))
+			return yyErrCode
+
+		default:
+			s.WriteRune(c)
+		}
 	}
 
 	if l.next() != '"' {
@@ -150,11 +319,132 @@ func (l *lex) str(lval *yySymType) int {
 		return yyErrCode
 	}
 	l.take()
+
+	if template {
+		if s.Len() > 0 {
+			segments = append(segments, String(s.String()))
+		}
+
+		lval.template = segments
+		return TEMPLATE
+	}
+
 	lval.str = s.String()
 
 	return STRING
 }
 
+func (l *lex) escape(s *strings.Builder) bool {
+	c := l.take()
+
+	switch c {
+	case '"', '\\', '/':
+		s.WriteRune(c)
+	case 'b':
+		s.WriteRune('\b')
+	case 'f':
+		s.WriteRune('\f')
+	case 'n':
+		s.WriteRune('\n')
+	case 'r':
+		s.WriteRune('\r')
+	case 't':
+		s.WriteRune('\t')
+
+	case 'u':
+		r, ok := l.hex()
+		if !ok {
+			l.Error(`invalid unicode escape in string, expected four hex digits after \u`)
+			return false
+		}
+
+		if utf16.IsSurrogate(r) {
+			i := l.i
+			pair := unicode.ReplacementChar
+			if l.match(`\u`) {
+				if low, ok := l.hex(); ok {
+					pair = utf16.DecodeRune(r, low)
+				}
+			}
+
+			if pair == unicode.ReplacementChar {
+				l.i = i
+			}
+			r = pair
+		}
+
+		s.WriteRune(r)
+
+	case EOF:
+		l.Error("improperly terminated string, reached EOF")
+		return false
+
+	default:
+		l.Error(fmt.Sprintf("invalid escape sequence \\%c in string", c))
+		return false
+	}
+
+	return true
+}
+
+func (l *lex) hex() (rune, bool) {
+	var r rune
+
+	for i := 0; i < 4; i++ {
+		c := l.next()
+
+		switch {
+		case '0' <= c && c <= '9':
+			r = r<<4 | (c - '0')
+		case 'a' <= c && c <= 'f':
+			r = r<<4 | (c - 'a' + 10)
+		case 'A' <= c && c <= 'F':
+			r = r<<4 | (c - 'A' + 10)
+		default:
+			return 0, false
+		}
+
+		l.take()
+	}
+
+	return r, true
+}
+
+// regex reads a regular expression delimited by slashes, where \/ stands
+// for a slash and every other escape is left for the regexp package.
+func (l *lex) regex(lval *yySymType) int {
+	l.take()
+	var s strings.Builder
+
+	for l.next() != '/' && l.next() != '\n' && l.next() != EOF {
+		c := l.take()
+
+		if c == '\\' && l.next() == '/' {
+			c = l.take()
+		} else if c == '\\' && l.next() != EOF {
+			s.WriteRune(c)
+			c = l.take()
+		}
+
+		s.WriteRune(c)
+	}
+
+	if l.next() != '/' {
+		l.Error("improperly terminated regular expression, expected closing /")
+		return yyErrCode
+	}
+	l.take()
+
+	re, err := regexp.Compile(s.String())
+	if err != nil {
+		l.Error(fmt.Sprintf("invalid regular expression /%s/: %s", s.String(), err))
+		return yyErrCode
+	}
+	lval.regex = re
+
+	return REGEX
+}
+
 func (l *lex) identifier(lval *yySymType) int {
 	var s strings.Builder
 	s.WriteRune(l.take())
@@ -165,5 +455,9 @@ func (l *lex) identifier(lval *yySymType) int {
 
 	lval.str = s.String()
 
+	if token, ok := keywords[lval.str]; ok {
+		return token
+	}
+
 	return IDENTIFIER
 }
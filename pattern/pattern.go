@@ -1,14 +1,20 @@
 package pattern
 
 import (
+	"sort"
 	"strings"
 )
 
 type bindings = map[string]interface{}
 type set = map[string]bool
 
+// anyName in a set stands for every name, so that references validate without
+// knowing what was bound before them. It is not a valid identifier.
+const anyName = "*"
+
 type Pattern interface {
 	Interpret(string) (bindings, error)
+	InterpretAll(string) ([]bindings, error)
 }
 
 type Validator interface {
@@ -32,3 +38,25 @@ const INDENT = "    "
 func indent(s string) string {
 	return INDENT + strings.ReplaceAll(s, "\n", "\n"+INDENT)
 }
+
+func copySet(s set) set {
+	c := set{}
+	for k, v := range s {
+		c[k] = v
+	}
+
+	return c
+}
+
+// added lists the names in after that are not in before, in sorted order.
+func added(before, after set) []string {
+	names := []string{}
+	for k := range after {
+		if !before[k] {
+			names = append(names, k)
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
@@ -2,6 +2,8 @@ package pattern_test
 
 import (
 	"encoding/json"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/xenomote/json_matcher/pattern"
@@ -32,6 +34,16 @@ func TestParse(t *testing.T) {
 		{"empty object", `{}`, true},
 		{"empty array", `[]`, true},
 
+		{"root string", `"ok"`, true},
+		{"root number", `42`, true},
+		{"root null", `null`, true},
+		{"root boolean", `true`, true},
+		{"root binding", `<=doc>`, true},
+		{"root bound object", `<=doc> {"a": <=x>}`, true},
+		{"root bound object with duplicate", `<=doc> {"a": <=doc>}`, false},
+		{"root reference", `<x>`, false},
+		{"root sequence", `1 2`, false},
+
 		{"object with one field", `{"a": 123}`, true},
 		{"object with one binding", `{"a": <=x>}`, true},
 		{"object with bound field", `{"a": <=x> 123}`, true},
@@ -41,10 +53,132 @@ func TestParse(t *testing.T) {
 		{"object with optional bound field", `{"a"?: <=x> 123}`, true},
 
 		{"object with reference", `{"a": <=x>, "b": <x>}`, true},
+		{"object with reference to bound field", `{"a": <=x> 1, "b": <x>}`, true},
+		{"object with duplicate bound field", `{"a": <=x> 1, "b": <=x>}`, false},
 		{"object with self reference", `{"a": <=x> <x>}`, false},
 		{"object with nested reference", `{"a": {"b": <=x>}, "c": <x>}`, true},
 		{"object with nested self reference", `{"a": <=x> {"b": <x>}}`, false},
 
+		{"object with path reference", `{"a": <=x>, "b": <x.y.z>}`, true},
+		{"object with numeric path reference", `{"a": <=x>, "b": <x.0.y>}`, true},
+		{"object with unbound path reference", `{"a": <=x>, "b": <y.x>}`, false},
+		{"object with path self reference", `{"a": <=x> <x.y>}`, false},
+
+		{"object with optional reference", `{"a"?: <=x>, "b": <x?>}`, true},
+		{"object with optional path reference", `{"a": <=x>, "b": <x.y?.z>}`, true},
+		{"object with unbound optional reference", `{"b": <x?>}`, false},
+
+		{"object with reference key", `{"a": <=k>, <k>: 1}`, true},
+		{"object with path reference key", `{"a": <=k>, <k.name>: 1}`, true},
+		{"object with unbound reference key", `{<k>: 1}`, false},
+		{"object with forward reference key", `{<k>: 1, "a": <=k>}`, false},
+		{"object with self reference key", `{<k>: <=k>}`, false},
+
+		{"object with wildcard", `{"a": _}`, true},
+		{"object with bound wildcard", `{"a": <=x> _}`, true},
+		{"object with many wildcards", `{"a": _, "b": _, "c"?: _}`, true},
+		{"object with wildcard key", `{_: 1}`, false},
+
+		{"object with type", `{"a": string, "b": number, "c": integer, "d": boolean, "e": object, "f": array}`, true},
+		{"object with bound type", `{"a": <=x> string}`, true},
+		{"object with inline bound type", `{"a": <=x: string>, "b": <x>}`, true},
+		{"object with inline bound duplicate", `{"a": <=x: string>, "b": <=x>}`, false},
+		{"object with unknown type", `{"a": text}`, false},
+		{"object with keyword prefixed binding", `{"a": <=nullable>, "b": <=trueish>}`, true},
+
+		{"object with alternation", `{"a": "active" | "pending"}`, true},
+		{"object with alternation of structures", `{"a": null | {"id": <=id>}}`, false},
+		{"object with alternation binding the same names", `{"a": <=id> string | {"id": <=id>}}`, true},
+		{"object with alternation binding different names", `{"a": <=x> | <=y>}`, false},
+		{"object with alternation reusing a binding", `{"a": <=x>, "b": 1 | <=x>}`, false},
+		{"object with reference after alternation", `{"a": <=x> 1 | <=x> 2, "b": <x>}`, true},
+		{"object with empty alternative", `{"a": 1 |}`, false},
+
+		{"object with negation", `{"a": !"deleted"}`, true},
+		{"object with negated type", `{"a": !array}`, true},
+		{"object with double negation", `{"a": !!1}`, true},
+		{"object with negated reference", `{"a": <=x>, "b": !<x>}`, true},
+		{"object with negated binding", `{"a": !<=x>}`, false},
+		{"object with nested negated binding", `{"a": !{"b": <=x>}}`, false},
+		{"object with reference to negated binding", `{"a": !{"b": <=x>}, "c": <x>}`, false},
+
+		{"empty closed object", `{||}`, true},
+		{"closed object", `{|"a": 1, "b"?: <=x>|}`, true},
+		{"closed object with trailing alternation", `{|"a": 1 | 2|}`, true},
+		{"closed object with mismatched brace", `{|"a": 1}`, false},
+		{"object with mismatched closed brace", `{"a": 1|}`, false},
+
+		{"object with rest", `{"type": <=t>, ...<=rest>}`, true},
+		{"object with only rest", `{...<=rest>}`, true},
+		{"object with duplicate rest", `{"a": <=rest>, ...<=rest>}`, false},
+		{"object with rest before fields", `{...<=rest>, "a": 1}`, false},
+		{"object with rest reference", `{...<rest>}`, false},
+		{"object with reference to rest", `{"a": {...<=rest>}, "b": <rest>}`, true},
+
+		{"object with absent key", `{"password"!}`, true},
+		{"object with absent and present keys", `{"user": <=u>, "password"!, "token"!}`, true},
+		{"object with absent reference key", `{"k": <=k>, <k>!}`, true},
+		{"object with absent key duplicating a field", `{"password": 1, "password"!}`, false},
+		{"object with duplicate absent keys", `{"password"!, "password"!}`, false},
+		{"object with absent key and value", `{"password"!: 1}`, false},
+		{"object with optional absent key", `{"password"?!}`, false},
+
+		{"object with descent", `{"payload": ..{"error": {"code": <=code>}}}`, true},
+		{"root descent with pointer", `..<=at> {"error": <=e>}`, true},
+		{"descent with duplicate pointer", `..<=e> {"error": <=e>}`, false},
+		{"descent into descent", `....[0: 1]`, false},
+		{"descent into spaced descent", `.. ..[0: 1]`, true},
+
+		{"object with every entry", `{*<=region>: {"status": <=s>}}`, true},
+		{"object with every unbound entry", `{*: number}`, true},
+		{"object with constrained entries", `{*<=k> "a" | <=k> "b": _}`, true},
+		{"entries with duplicate binding", `{*<=k>: <=k>}`, false},
+		{"entries binding clashes with field", `{"a": <=k>, *<=k>: _}`, false},
+
+		{"regex", `/^user-\d+$/`, true},
+		{"regex with capture group", `{"user": /^user-(?P<id>\d+)$/}`, true},
+		{"regex with escaped slash", `/a\/b/`, true},
+		{"invalid regex", `/a(/`, false},
+		{"unterminated regex", `/abc`, false},
+		{"regex group clashes with binding", `{"a": <=id>, "b": /(?P<id>.*)/}`, false},
+		{"regex group referenced later", `{"a": /(?P<id>.*)/, "b": <id>}`, true},
+		{"regex as entry key constraint", `{*<=k> /^us-/: _}`, true},
+
+		{"template", `$"order-<=id>-<=region>"`, true},
+		{"template with reference", `{"id": <=id>, "ref": $"order-<id>"}`, true},
+		{"template reference before binding", `{"ref": $"order-<id>", "id": <=id>}`, false},
+		{"template referencing own binding", `$"<=a>-<a>"`, false},
+		{"template with duplicate binding", `$"<=a>-<=a>"`, false},
+		{"template binding clashes with field", `{"a": <=id>, "b": $"x-<=id>"}`, false},
+		{"template with escaped placeholder", `$"\<=a>"`, true},
+		{"template with adjacent bindings", `$"<=a><=b>"`, false},
+		{"template with bindings divided by reference", `{"r": <=r>, "s": $"<=a><r><=b>"}`, false},
+		{"template with bindings divided by text", `$"<=a>-<=b>"`, true},
+		{"plain string with placeholder text", `{"a": "<b>"}`, true},
+		{"escaped < in plain string", `"\<"`, false},
+		{"$ without string", `$1`, false},
+		{"template as key", `{$"<=a>": 1}`, false},
+
+		{"range", `1..100`, true},
+		{"range with reference", `{"max": <=max>, "n": 0..<max>}`, true},
+		{"range with unbound reference", `{"n": 0..<max>}`, false},
+		{"empty range", `5..1`, false},
+		{"range in tuple", `[1..2, 3]`, true},
+		{"comparison", `>= 0`, true},
+		{"comparison with reference", `{"limit": <=limit>, "n": < <limit>}`, true},
+		{"comparison with unbound reference", `{"n": <= <limit>}`, false},
+		{"bound comparison", `<=n> > 0`, true},
+		{"comparison without space", `{"a": <5}`, true},
+		{"less or equal without space", `{"a": <=5}`, true},
+		{"greater without space", `{"a": >5}`, true},
+		{"greater or equal without space", `{"a": >=5}`, true},
+		{"less without space before reference", `{"l": <=l>, "a": <<l>}`, true},
+		{"less or equal negative", `<=-1`, true},
+
+		{"binding named after a type", `{"a": <=number>}`, true},
+		{"reference through type named field", `{"x": <=x>, "y": <x.object>}`, true},
+		{"type binding named after a type", `<=string:string>`, true},
+
 		{"object with nested object", `{"a": {}}`, true},
 		{"object with nested array", `{"a": []}`, true},
 
@@ -64,11 +198,103 @@ func TestParse(t *testing.T) {
 		{"array with optional binding", `[0?: <=x>]`, true},
 		{"array with optional bound field", `[0?: <=x> 1]`, true},
 
+		{"array with reference index", `[0: <=i>, <i>: 1]`, true},
+		{"array with unbound reference index", `[<i>: 1]`, false},
+		{"array with forward reference index", `[<i>: 1, 0: <=i>]`, false},
+
+		{"tuple", `[<=lat>, <=lon>]`, true},
+		{"tuple with unbound reference", `[1, "a", <x?>]`, false},
+		{"tuple of values", `[1, "a", null, _, number | string]`, true},
+		{"tuple with reference", `[<=x>, <x>]`, true},
+		{"tuple with duplicate binding", `[<=x>, <=x>]`, false},
+		{"exact tuple", `[|<=lat>, <=lon>|]`, true},
+		{"empty exact tuple", `[||]`, true},
+		{"tuple mixed with indices", `[<=x>, 1: <=y>]`, false},
+		{"indices mixed with tuple", `[0: <=x>, <=y>]`, false},
+
+		{"array with slice", `[1..: <=tail>]`, true},
+		{"array with bounded slice", `[2..5: <=window>]`, true},
+		{"array with slice to end", `[..-1: <=init>]`, true},
+		{"array with reference slice", `[0: <=n>, 1..<n>: <=items>]`, true},
+		{"array with unbound reference slice", `[1..<n>: <=items>]`, false},
+		{"array with fractional slice", `[1..2.5: <=items>]`, false},
+		{"array with overlapping slices", `[1..: <=a>, 2..5: <=b>]`, false},
+		{"array with overlapping negative slices", `[-3..: <=a>, ..-1: <=b>]`, false},
+		{"array with overlapping open slices", `[..2: <=a>, ..3: <=b>]`, false},
+		{"array with adjacent slices", `[..1: <=a>, 2..: <=b>]`, true},
+		{"array with slices sharing a bound", `[..2: <=a>, 2..: <=b>]`, false},
+		{"array with disjoint negative slices", `[..2: <=a>, -2..: <=b>]`, true},
+		{"array with empty slice", `[3..1: <=a>, 0..5: <=b>]`, true},
+		{"array with duplicate slice", `[1..: <=a>, 1..: <=b>]`, false},
+		{"array with slice overlapping an index", `[0: <=first>, 0..: <=all>]`, true},
+
+		{"array with exact length", `[]#3`, true},
+		{"array with minimum length", `[0: <=x>]#1..`, true},
+		{"array with maximum length", `[0?: <=x>]#..5`, true},
+		{"array with bounded length", `[<=x>, <=y>]#2..5`, true},
+		{"array with fractional length", `[]#1.5`, false},
+		{"array with negative length", `[]#..-1`, false},
+		{"array with inverted length", `[]#5..2`, false},
+		{"array with length below required index", `[3: <=x>]#..3`, false},
+		{"array with length below required negative index", `[-3: <=x>]#..2`, false},
+		{"array with length below optional index", `[3?: <=x>]#..3`, true},
+		{"array with length conflicting with exact tuple", `[|<=x>, <=y>|]#3`, false},
+		{"array with length admitting exact tuple", `[|<=x>, <=y>|]#1..2`, true},
+
+		{"array with every element", `[*: {"id": <=ids>, "qty": number}]`, true},
+		{"array with every element and index", `[0: <=first>, *: number]`, true},
+		{"array with reference to every element binding", `{"a": [*: <=xs>], "b": <xs>}`, true},
+		{"array with every element rebinding", `[0: <=x>, *: <=x>]`, false},
+		{"array with duplicate every element", `[*: number, *: <=x>]`, false},
+		{"array with optional every element", `[*?: number]`, false},
+
+		{"array with some element", `[?: {"key": "env", "value": <=env>}]`, true},
+		{"array with some element position", `[?<=i>: {"key": "env"}]`, true},
+		{"array with some element rebinding position", `[0: <=i>, ?<=i>: 1]`, false},
+		{"array with some element position referenced inside", `[?<=i>: <i>]`, false},
+		{"array with reference to some element binding", `{"a": [?: <=x>], "b": <x>}`, true},
+		{"array with repeated some elements", `[?: 1, ?: 2]`, true},
+
+		{"unordered array", `[& "admin", "read"]`, true},
+		{"exact unordered array", `[|& "admin", <=other>|]`, true},
+		{"unordered array with reference", `[& <=x> number, <x>]`, true},
+		{"unordered array with duplicate binding", `[& <=x>, <=x>]`, false},
+		{"empty unordered array", `[&]`, false},
+
 		{"array with nested object", `[0: {}]`, true},
 		{"array with nested array", `[0: []]`, true},
 
 		{"array with duplicate index", `[0: 1, 0: 2]`, false},
 		{"array with string index", `["a": 123]`, false},
+		{"array with fractional index", `[1.5: 1]`, false},
+		{"array with negative index", `[-1: 1]`, true},
+		{"array with fractional negative index", `[-1.5: 1]`, false},
+		{"array with exponent index", `[1e1: 1]`, true},
+
+		{"negative number", `{"a": -3}`, true},
+		{"decimal number", `{"a": 3.25}`, true},
+		{"exponent number", `{"a": 1e-3}`, true},
+		{"signed exponent number", `[0: -2.5E+10]`, true},
+		{"zero", `{"a": 0}`, true},
+		{"negative zero", `{"a": -0.0}`, true},
+		{"number with leading zero", `{"a": 01}`, false},
+		{"number with lone minus", `{"a": -}`, false},
+		{"number with double minus", `{"a": --1}`, false},
+		{"number with empty fraction", `{"a": 1.}`, false},
+		{"number with empty exponent", `{"a": 1e}`, false},
+		{"number with empty signed exponent", `{"a": 1e+}`, false},
+		{"number with leading decimal point", `{"a": .5}`, false},
+		{"number with trailing letter", `{"a": 1.5x}`, false},
+
+		{"string with escaped quote", `{"a": "x\"y"}`, true},
+		{"string with escapes", `{"a": "\\\/\b\f\n\r\t"}`, true},
+		{"string with unicode escape", `{"caf\u00e9": 1}`, true},
+		{"string with surrogate pair", `{"a": "\ud83d\ude00"}`, true},
+		{"string with invalid escape", `{"a": "\x"}`, false},
+		{"string with short unicode escape", `{"a": "\u12"}`, false},
+		{"string with raw newline", "{\"a\": \"x\ny\"}", false},
+		{"string with unterminated escape", `{"a": "\`, false},
+		{"object with duplicate escaped key", `{"a": 1, "\u0061": 2}`, false},
 	}
 
 	for _, test := range tests {
@@ -87,6 +313,55 @@ func TestParse(t *testing.T) {
 	}
 }
 
+func TestString(t *testing.T) {
+	tests := []string{
+		`{"a": 1, "b"?: <=x>, "c": <x>}`,
+		`[0: "x", 1?: <=y> [0: null]]`,
+		`{"a\"b": "c\\d", "\u00e9\n": "<&>"}`,
+		`{"a": <=x>, "b": <x.y?.z>, "c": <x?.0>}`,
+		`{"a": <=k>, <k>: [0: <=i>, <i.j>?: 1]}`,
+		`<=doc> "ok"`,
+		`{"a": _, "b": [0?: _]}`,
+		`{"a": <=x: integer>, "b": [0: object, 1: array]}`,
+		`{"a": "x" | null | {}, "b": <=y> 1 | <=y>}`,
+		`{"a": !"x", "b": !{"c": !null}}`,
+		`{|"a": {||}, "b": {|"c"?: 1 | 2|}|}`,
+		`{"a": {...<=x>}, "b": 1, ...<=y>}`,
+		`{"a": 1, "b"!, "c": {"d"!}}`,
+		`[<=x>, [|1 | 2, {}|], [||], [0?: _]]`,
+		`[-1: 1, 2..-1: _, ..1: _, 0: <=n>, ..<n>?: _]`,
+		`[[]#2, [0: 1]#1.., [|_|]#..3, [_, _]#2..4]`,
+		`[0: <=x>, *: {"a": <=ys>}]#1..`,
+		`[?: 1, ?<=i>: {"a": 2}]`,
+		`[[& 1, <=x>], [|& _|]#1]`,
+		`{"a": ..<=p> {"b": <=c>}, "d": ..[?: 1]}`,
+		`{"a": 1, *<=k> string: {*: _}, *"b" | "c": <=v>}`,
+		`[/^a\/(?P<x>b+)$/, !/c/]`,
+		`{"a": <=a>, "b": $"\\<a>-<=b>\"<=c>\u0001\<=d>"}`,
+		`{"a": <=a>, "b": [1..<a>, < <a.b>, >= -1, <=c> > 0]}`,
+	}
+
+	for _, test := range tests {
+		t.Run(test, func(t *testing.T) {
+			p, err := pattern.Parse(test)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			printed := fmt.Sprint(p)
+
+			q, err := pattern.Parse(printed)
+			if err != nil {
+				t.Fatalf("printed pattern %s failed to parse: %s", printed, err)
+			}
+
+			if reprinted := fmt.Sprint(q); printed != reprinted {
+				t.Fatalf("printed pattern did not round trip: \n%s != \n%s", printed, reprinted)
+			}
+		})
+	}
+}
+
 type bindings = map[string]interface{}
 
 func TestInterpret(t *testing.T) {
@@ -99,6 +374,19 @@ func TestInterpret(t *testing.T) {
 		{`{}`, `[]`, false, ``},
 		{`[]`, `{}`, false, ``},
 
+		{`"ok"`, `"ok"`, true, `{}`},
+		{`"ok"`, `"fail"`, false, ``},
+		{`42`, `42`, true, `{}`},
+		{`42`, ` 42 `, true, `{}`},
+		{`42`, `"42"`, false, ``},
+		{`null`, `null`, true, `{}`},
+		{`null`, `{}`, false, ``},
+		{`false`, `false`, true, `{}`},
+		{`<=doc>`, `"ok"`, true, `{"doc": "ok"}`},
+		{`<=doc>`, `{"a": [1]}`, true, `{"doc": {"a": [1]}}`},
+		{`<=doc> {"a": <=x>}`, `{"a": 1}`, true, `{"doc": {"a": 1}, "x": 1}`},
+		{`<=doc> {"a": <=x>}`, `{"b": 1}`, false, ``},
+
 		{`{}`, `{}`, true, `{}`},
 		{`{}`, `{"a": 1, "b": 2, "c": 3}`, true, `{}`},
 
@@ -117,6 +405,197 @@ func TestInterpret(t *testing.T) {
 		{`{"b": <=y>, "a": <=x>}`, `{"a": 1, "b": 2}`, true, `{"x": 1, "y": 2}`},
 		{`{"b": <=y>, "a": <=x>}`, `{"b": 2, "a": 1}`, true, `{"x": 1, "y": 2}`},
 
+		{`{"a": -3.5}`, `{"a": -3.5}`, true, `{}`},
+		{`{"a": -3.5}`, `{"a": 3.5}`, false, ``},
+		{`{"a": 1e-3}`, `{"a": 0.001}`, true, `{}`},
+		{`{"a": 1E3}`, `{"a": 1000}`, true, `{}`},
+		{`[2e0: 1]`, `[0, 0, 1]`, true, `{}`},
+
+		{`{"a": "x\"y"}`, `{"a": "x\"y"}`, true, `{}`},
+		{`{"a": "café"}`, `{"a": "caf\u00e9"}`, true, `{}`},
+		{`{"a": "caf\u00e9"}`, `{"a": "café"}`, true, `{}`},
+		{`{"a": "\n"}`, `{"a": "\u000a"}`, true, `{}`},
+		{`{"a": "\ud83d\ude00"}`, `{"a": "😀"}`, true, `{}`},
+		{`{"a": "x"}`, `{"a": "y"}`, false, ``},
+		{`{"a": "1"}`, `{"a": 1}`, false, ``},
+		{`{"caf\u00e9": 1}`, `{"café": 1}`, true, `{}`},
+
+		{`{"a": _}`, `{"a": 1}`, true, `{}`},
+		{`{"a": _}`, `{"a": {"b": [null]}}`, true, `{}`},
+		{`{"a": _}`, `{"a": null}`, true, `{}`},
+		{`{"a": _}`, `{}`, false, ``},
+		{`{"a"?: _}`, `{}`, true, `{}`},
+		{`[0: _, 1: <=x>]`, `["a", "b"]`, true, `{"x": "b"}`},
+		{`[0: _, 1: <=x>]`, `["a"]`, false, ``},
+		{`_`, `[1, 2]`, true, `{}`},
+
+		{`{"a": string}`, `{"a": "x"}`, true, `{}`},
+		{`{"a": string}`, `{"a": 1}`, false, ``},
+		{`{"a": number}`, `{"a": 1.5}`, true, `{}`},
+		{`{"a": number}`, `{"a": "1"}`, false, ``},
+		{`{"a": integer}`, `{"a": 2}`, true, `{}`},
+		{`{"a": integer}`, `{"a": 2.0}`, true, `{}`},
+		{`{"a": integer}`, `{"a": 2.5}`, false, ``},
+		{`{"a": integer}`, `{"a": true}`, false, ``},
+		{`{"a": boolean}`, `{"a": false}`, true, `{}`},
+		{`{"a": boolean}`, `{"a": null}`, false, ``},
+		{`{"a": object}`, `{"a": {"b": 1}}`, true, `{}`},
+		{`{"a": object}`, `{"a": [1]}`, false, ``},
+		{`{"a": array}`, `{"a": []}`, true, `{}`},
+		{`{"a": array}`, `{"a": {}}`, false, ``},
+		{`{"id": <=id: string>}`, `{"id": "x"}`, true, `{"id": "x"}`},
+		{`{"id": <=id: string>}`, `{"id": 1}`, false, ``},
+		{`{"age": <=age> integer}`, `{"age": 30}`, true, `{"age": 30}`},
+		{`number`, `-1e3`, true, `{}`},
+
+		{`{"a": "active" | "pending"}`, `{"a": "active"}`, true, `{}`},
+		{`{"a": "active" | "pending"}`, `{"a": "pending"}`, true, `{}`},
+		{`{"a": "active" | "pending"}`, `{"a": "deleted"}`, false, ``},
+		{`{"a": <=id> string | {"id": <=id>}}`, `{"a": "x"}`, true, `{"id": "x"}`},
+		{`{"a": <=id> string | {"id": <=id>}}`, `{"a": {"id": 1}}`, true, `{"id": 1}`},
+		{`{"a": <=id> string | {"id": <=id>}}`, `{"a": 1}`, false, ``},
+		{`{"a": null | {"id": 1}}`, `{"a": null}`, true, `{}`},
+		{`[0: <=x> 1 | <=x> 2, 1: <x>]`, `[2, 2]`, true, `{"x": 2}`},
+		{`[0: <=x> 1 | <=x> 2, 1: <x>]`, `[2, 1]`, false, ``},
+		{`<=x> number | <=x> string`, `"a"`, true, `{"x": "a"}`},
+
+		{`{"status": !"deleted"}`, `{"status": "active"}`, true, `{}`},
+		{`{"status": !"deleted"}`, `{"status": "deleted"}`, false, ``},
+		{`{"payload": !array}`, `{"payload": {}}`, true, `{}`},
+		{`{"payload": !array}`, `{"payload": [1]}`, false, ``},
+		{`{"a": !!1}`, `{"a": 1}`, true, `{}`},
+		{`{"a": !{"b": 1}}`, `{"a": {"b": 2}}`, true, `{}`},
+		{`{"a": <=x>, "b": !<x>}`, `{"a": 1, "b": 2}`, true, `{"x": 1}`},
+		{`{"a": <=x>, "b": !<x>}`, `{"a": 1, "b": 1}`, false, ``},
+		{`{"a": !"x" | "y"}`, `{"a": "y"}`, true, `{}`},
+
+		{`{||}`, `{}`, true, `{}`},
+		{`{||}`, `{"a": 1}`, false, ``},
+		{`{|"a": 1|}`, `{"a": 1}`, true, `{}`},
+		{`{|"a": 1|}`, `{"a": 1, "b": 2, "c": 3}`, false, ``},
+		{`{|"a": 1, "b"?: <=x>|}`, `{"a": 1}`, true, `{}`},
+		{`{|"a": 1, "b"?: <=x>|}`, `{"a": 1, "b": 2}`, true, `{"x": 2}`},
+		{`{|"k": <=k>, <k>: _|}`, `{"k": "a", "a": 1}`, true, `{"k": "a"}`},
+		{`{|"k": <=k>, <k>: _|}`, `{"k": "b", "a": 1, "b": 1}`, false, ``},
+		{`{"a": {||}}`, `{"a": {}, "b": 1}`, true, `{}`},
+
+		{`{"type": <=t>, ...<=rest>}`, `{"type": "click", "x": 1, "y": [2]}`, true, `{"t": "click", "rest": {"x": 1, "y": [2]}}`},
+		{`{"type": <=t>, ...<=rest>}`, `{"type": "click"}`, true, `{"t": "click", "rest": {}}`},
+		{`{"type": <=t>, ...<=rest>}`, `{"x": 1}`, false, ``},
+		{`{"a"?: 1, ...<=rest>}`, `{"b": 2}`, true, `{"rest": {"b": 2}}`},
+		{`{...<=rest>}`, `{"a": {"b": null}}`, true, `{"rest": {"a": {"b": null}}}`},
+		{`{"k": <=k>, <k>: _, ...<=rest>}`, `{"k": "a", "a": 1, "b": 2}`, true, `{"k": "a", "rest": {"b": 2}}`},
+
+		{`{"password"!}`, `{}`, true, `{}`},
+		{`{"password"!}`, `{"user": "x"}`, true, `{}`},
+		{`{"password"!}`, `{"password": null}`, false, ``},
+		{`{"user": <=u>, "password"!}`, `{"user": "x", "password": "y"}`, false, ``},
+		{`{"k": <=k>, <k>!}`, `{"k": "a", "b": 1}`, true, `{"k": "a"}`},
+		{`{"k": <=k>, <k>!}`, `{"k": "k"}`, false, ``},
+		{`{|"a": 1, "b"!|}`, `{"a": 1}`, true, `{}`},
+		{`{"a"!, ...<=rest>}`, `{"b": 1}`, true, `{"rest": {"b": 1}}`},
+
+		{`..{"error": {"code": <=code>}}`, `{"error": {"code": 1}}`, true, `{"code": 1}`},
+		{`..{"error": {"code": <=code>}}`, `{"a": [{"b": {"error": {"code": 2}}}]}`, true, `{"code": 2}`},
+		{`..{"error": {"code": <=code>}}`, `{"a": [{"b": {"error": 1}}]}`, false, ``},
+		{`..<=at> {"error": {"code": <=code>}}`, `{"a": [0, {"b/c": {"error": {"code": 3}}}]}`, true, `{"code": 3, "at": "/a/1/b~1c"}`},
+		{`..<=at> {"code": <=code>}`, `{"b": {"code": 2}, "a": {"code": 1}}`, true, `{"code": 1, "at": "/a"}`},
+		{`..<=at> [0: "x"]`, `[["y"], ["x"]]`, true, `{"at": "/1"}`},
+		{`{"want": <=w>, "data": ..{"id": <w>, "v": <=v>}}`, `{"want": 2, "data": [{"id": 1, "v": "a"}, {"id": 2, "v": "b"}]}`, true, `{"w": 2, "v": "b"}`},
+		{`..<=at> 1`, `[0, {"a": 1}]`, true, `{"at": "/1/a"}`},
+		{`..<=at> string`, `{"b": [1, "x"], "a": 2}`, true, `{"at": "/b/1"}`},
+		{`..true`, `{"a": [false]}`, false, ``},
+		{`{"a": ..<=x> number}`, `{"a": 3}`, true, `{"x": ""}`},
+
+		{`{*<=region>: {"status": <=s>}}`, `{"us-east-1": {"status": "up"}, "eu-west-1": {"status": "down"}}`, true, `{"region": ["eu-west-1", "us-east-1"], "s": ["down", "up"]}`},
+		{`{*<=region>: {"status": <=s>}}`, `{}`, true, `{"region": [], "s": []}`},
+		{`{*<=region>: {"status": <=s>}}`, `{"a": {"status": 1}, "b": {}}`, false, ``},
+		{`{*: number}`, `{"a": 1, "b": 2}`, true, `{}`},
+		{`{*<=k> "a" | <=k> "b": <=v>}`, `{"a": 1, "b": 2, "c": "x"}`, true, `{"k": ["a", "b"], "v": [1, 2]}`},
+		{`{"version": <=n>, *<=k> !"version": {"id": <=id>}}`, `{"version": 2, "x": {"id": 1}}`, true, `{"n": 2, "k": ["x"], "id": [1]}`},
+		{`{| *<=k> "a" | <=k> "b": _ |}`, `{"a": 1, "b": 2}`, true, `{"k": ["a", "b"]}`},
+		{`{| *<=k> "a" | <=k> "b": _ |}`, `{"a": 1, "c": 2}`, false, ``},
+		{`{*<=k> "a" | <=k> "b": _, ...<=rest>}`, `{"a": 1, "c": 2}`, true, `{"k": ["a"], "rest": {"c": 2}}`},
+		{`{*: [?: <=x>]}`, `{"a": [1, 2]}`, true, `{"x": [1]}`},
+		{`[*: {*<=k>: _}]`, `[{"a": 1}, {"b": 1, "c": 2}]`, true, `{"k": [["a"], ["b", "c"]]}`},
+
+		{`/^user-(?P<id>\d+)$/`, `"user-42"`, true, `{"id": "42"}`},
+		{`/^user-(?P<id>\d+)$/`, `"user-x"`, false, ``},
+		{`/^user-(?P<id>\d+)$/`, `42`, false, ``},
+		{`/^(?P<a>x)|(?P<b>y)$/`, `"y"`, true, `{"a": null, "b": "y"}`},
+		{`/a\/b/`, `"xa/by"`, true, `{}`},
+		{`/^$/`, `null`, false, ``},
+		{`/(?P<x>.*)/`, `null`, false, ``},
+		{`{"a": /(?P<id>\d+)/, "b": <id>}`, `{"a": "n7", "b": "7"}`, true, `{"id": "7"}`},
+		{`{"a": /(?P<id>\d+)/, "b": <id>}`, `{"a": "n7", "b": "8"}`, false, ``},
+		{`{*<=k> /^us-/: <=v>}`, `{"us-east-1": 1, "eu-west-1": 2}`, true, `{"k": ["us-east-1"], "v": [1]}`},
+		{`[*: /-(?P<n>\d)$/]`, `["a-1", "b-2"]`, true, `{"n": ["1", "2"]}`},
+
+		{`$"order-<=id>-<=region>"`, `"order-17-eu"`, true, `{"id": "17", "region": "eu"}`},
+		{`$"order-<=id>-<=region>"`, `"order-17-eu-west-1"`, true, `{"id": "17", "region": "eu-west-1"}`},
+		{`$"order-<=id>-<=region>"`, `"invoice-17-eu"`, false, ``},
+		{`$"order-<=id>-<=region>"`, `17`, false, ``},
+		{`$"<=all>"`, `""`, true, `{"all": ""}`},
+		{`{"id": <=id>, "ref": $"order-<id>"}`, `{"id": 17, "ref": "order-17"}`, true, `{"id": 17}`},
+		{`{"id": <=id>, "ref": $"order-<id>"}`, `{"id": "a.b", "ref": "order-axb"}`, false, ``},
+		{`{"o": <=o>, "ref": $"<o.kind>/<=n>"}`, `{"o": {"kind": "x"}, "ref": "x/9"}`, true, `{"o": {"kind": "x"}, "n": "9"}`},
+		{`$"\<=a>"`, `"<=a>"`, true, `{}`},
+		{`{"r"?: <=r>, "s": $"x-<r?>-<=n>"}`, `{"s": "x-any-thing-9"}`, true, `{"n": "thing-9"}`},
+		{`{"r"?: <=r>, "s": $"x-<r?>-<=n>"}`, `{"r": "a", "s": "x-a-9"}`, true, `{"r": "a", "n": "9"}`},
+		{`{"r"?: <=r>, "s": $"x-<r?>-<=n>"}`, `{"r": "a", "s": "x-b-9"}`, false, ``},
+		{`$"<=all>"`, `null`, false, ``},
+		{`{"b": <=b>, "a": "<b>"}`, `{"b": 1, "a": "<b>"}`, true, `{"b": 1}`},
+		{`"<=a>"`, `"<=a>"`, true, `{}`},
+		{`"<&>"`, `"<&>"`, true, `{}`},
+
+		{`1..100`, `1`, true, `{}`},
+		{`1..100`, `100`, true, `{}`},
+		{`1..100`, `250`, false, ``},
+		{`1..100`, `"50"`, false, ``},
+		{`0..10`, `null`, false, ``},
+		{`>= 0`, `null`, false, ``},
+		{`< 5`, `null`, false, ``},
+		{`{"max": <=max>, "n": 0..<max>}`, `{"max": 10, "n": 10}`, true, `{"max": 10}`},
+		{`{"max": <=max>, "n": 0..<max>}`, `{"max": 10, "n": 11}`, false, ``},
+		{`{"max": <=max>, "n": 0..<max>}`, `{"max": "10", "n": 1}`, false, ``},
+		{`>= 0`, `0`, true, `{}`},
+		{`>= 0`, `-0.5`, false, ``},
+		{`> 0`, `0`, false, ``},
+		{`< 1.5`, `1`, true, `{}`},
+		{`<= -1`, `-1`, true, `{}`},
+		{`<5`, `4`, true, `{}`},
+		{`<5`, `5`, false, ``},
+		{`<=5`, `5`, true, `{}`},
+		{`<=-1`, `0`, false, ``},
+		{`>5`, `6`, true, `{}`},
+		{`>=5`, `4`, false, ``},
+		{`{"l": <=l>, "a": <<l>}`, `{"l": 3, "a": 2}`, true, `{"l": 3}`},
+		{`{"limit": <=limit>, "n": < <limit>}`, `{"limit": 3, "n": 2}`, true, `{"limit": 3}`},
+		{`{"limit": <=limit>, "n": < <limit>}`, `{"limit": 3, "n": 3}`, false, ``},
+		{`<=n> > 0`, `2`, true, `{"n": 2}`},
+		{`{"l"?: <=l>, "n": < <l?>}`, `{"n": 5}`, true, `{}`},
+		{`{"l"?: <=l>, "n": < <l?>}`, `{"l": 3, "n": 5}`, false, ``},
+		{`{"l"?: <=l>, "n": > <l?>}`, `{"n": -5}`, true, `{}`},
+		{`{"l"?: <=l>, "n": <l?>..10}`, `{"n": -5}`, true, `{}`},
+		{`{"l"?: <=l>, "n": <l?>..10}`, `{"n": 11}`, false, ``},
+		{`{"l"?: <=l>, "n": 0..<l?>}`, `{"n": 1e9}`, true, `{}`},
+		{`{"l"?: <=l>, "n": 0..<l?>}`, `{"l": 3, "n": 4}`, false, ``},
+		{`[*: > 0 | "none"]`, `[1, "none", 2]`, true, `{}`},
+		{`[1..2: [*: 1..2]]`, `[0, 1, 2, 3]`, true, `{}`},
+
+		{`{"a": ""}`, `{"a": null}`, false, ``},
+		{`""`, `null`, false, ``},
+
+		{`{"a": <=number>, "b": <number>}`, `{"a": 1, "b": 1}`, true, `{"number": 1}`},
+		{`{"x": <=x>, "y": <x.object>}`, `{"x": {"object": 2}, "y": 2}`, true, `{"x": {"object": 2}}`},
+		{`{"a": <=array>, "b": $"<array>-<=string>"}`, `{"a": 1, "b": "1-x"}`, true, `{"array": 1, "string": "x"}`},
+
+		{`{"tags": [?: <=t>], "want": <t>}`, `{"tags": ["a", "b"], "want": "b"}`, true, `{"t": "b"}`},
+		{`[*: [?: <=x>]]`, "[" + strings.Repeat(`[1, 2], `, 40) + "[1, 2]]", true, `{"x": [` + strings.Repeat(`1, `, 40) + `1]}`},
+		{`{"a": [*: [?: <=x>]], "b": <x.40>}`, `{"a": [` + strings.Repeat(`[1, 2], `, 40) + `[1, 2]], "b": 2}`, true, `{"x": [` + strings.Repeat(`1, `, 40) + `2]}`},
+		{`{"a": {"b": [?: <=x>], "c": [?: <=y>], "d": [?: <=z>]}}`, `{"a": {"b": [` + strings.Repeat(`1, `, 59) + `1], "c": [` + strings.Repeat(`2, `, 59) + `2], "d": [` + strings.Repeat(`3, `, 59) + `3]}}`, true, `{"x": 1, "y": 2, "z": 3}`},
+		{`{"a": {"b": [?: <=x>], "c": [?: <=y>]}, "d": [<x>, <y>]}`, `{"a": {"b": [` + strings.Repeat(`0, `, 59) + `1], "c": [` + strings.Repeat(`0, `, 59) + `2]}, "d": [1, 2]}`, true, `{"x": 1, "y": 2}`},
+
 		{`{"a"?: <=x>}`, `{"a": 1}`, true, `{"x": 1}`},
 		{`{"a"?: <=x>}`, `{}`, true, `{}`},
 
@@ -147,9 +626,117 @@ func TestInterpret(t *testing.T) {
 		{`{"a": {"b": <=x>}, "c": <x>}`, `{"a": {"b": 1}, "c": 1}`, true, `{"x": 1}`},
 		{`{"a": {"b": <=x>}, "c": <x>}`, `{"a": {"b": 1}, "c": 2}`, false, ``},
 
+		{`{"a": <=x>, "b": <x.y.z>}`, `{"a": {"y": {"z": 1}}, "b": 1}`, true, `{"x": {"y": {"z": 1}}}`},
+		{`{"a": <=x>, "b": <x.y.z>}`, `{"a": {"y": {"z": 1}}, "b": 2}`, false, ``},
+		{`{"a": <=x>, "b": <x.y.z>}`, `{"a": {"y": {}}, "b": 1}`, false, ``},
+		{`{"a": <=x>, "b": <x.y.z>}`, `{"a": {"y": 1}, "b": 1}`, false, ``},
+		{`{"a": <=x>, "b": <x.y>}`, `{"a": {"y": {"z": [1]}}, "b": {"z": [1]}}`, true, `{"x": {"y": {"z": [1]}}}`},
+		{`{"a": <=x>, "b": <x.1.y>}`, `{"a": [0, {"y": true}], "b": true}`, true, `{"x": [0, {"y": true}]}`},
+		{`{"a": <=x>, "b": <x.2>}`, `{"a": [0, 1], "b": 1}`, false, ``},
+		{`{"a": <=x>, "b": <x.y>}`, `{"a": [0, 1], "b": 1}`, false, ``},
+		{`[0: <=x>, 1: <x.10>]`, `[[0, 1, 2, 3, 4, 5, 6, 7, 8, 9, "ten"], "ten"]`, true, `{"x": [0, 1, 2, 3, 4, 5, 6, 7, 8, 9, "ten"]}`},
+
+		{`{"a"?: <=x>, "b": <x?>}`, `{"b": 1}`, true, `{}`},
+		{`{"a"?: <=x>, "b": <x?>}`, `{"a": 1, "b": 1}`, true, `{"x": 1}`},
+		{`{"a"?: <=x>, "b": <x?>}`, `{"a": 1, "b": 2}`, false, ``},
+		{`{"a"?: <=x>, "b": <x>}`, `{"b": 1}`, false, ``},
+		{`{"a": <=x>, "b": <x.y?>}`, `{"a": {}, "b": 1}`, true, `{"x": {}}`},
+		{`{"a": <=x>, "b": <x.y?>}`, `{"a": {"y": 2}, "b": 1}`, false, ``},
+		{`{"a": <=x>, "b": <x.y?.z>}`, `{"a": {}, "b": 1}`, true, `{"x": {}}`},
+		{`{"a": <=x>, "b": <x.y?.z>}`, `{"a": {"y": {}}, "b": 1}`, false, ``},
+		{`{"a"?: <=x>, "b": <x?.y>}`, `{"b": 1}`, true, `{}`},
+		{`{"a"?: <=x>, "b": <x?.y>}`, `{"a": {}, "b": 1}`, false, ``},
+
+		{`{"selected": <=k>, <k>: <=v>}`, `{"selected": "b", "a": 1, "b": 2}`, true, `{"k": "b", "v": 2}`},
+		{`{"selected": <=k>, <k>: <=v>}`, `{"selected": "c", "a": 1, "b": 2}`, false, ``},
+		{`{"selected": <=k>, <k>: <=v>}`, `{"selected": 1, "a": 1, "b": 2}`, false, ``},
+		{`{"selected": <=k>, <k>?: <=v>}`, `{"selected": "c"}`, true, `{"k": "c"}`},
+		{`{"s": <=k>, "o": {<k.name>: <=v>}}`, `{"s": {"name": "a"}, "o": {"a": 1}}`, true, `{"k": {"name": "a"}, "v": 1}`},
+
+		{`[0: <=i>, <i>: <=v>]`, `[2, "a", "b"]`, true, `{"i": 2, "v": "b"}`},
+		{`[0: <=i>, <i>: <=v>]`, `[3, "a", "b"]`, false, ``},
+		{`[0: <=i>, <i>: <=v>]`, `[0.5, "a", "b"]`, false, ``},
+		{`[0: <=i>, <i>: <=v>]`, `["1", "a", "b"]`, false, ``},
+		{`[0: <=i>, <i>?: <=v>]`, `[3, "a", "b"]`, true, `{"i": 3}`},
+
 		{`[0: <=x>, 1: <x>]`, `[1, 1]`, true, `{"x": 1}`},
 		{`[0: <=x>, 1: <x>]`, `[1, 2]`, false, ``},
 
+		{`[<=lat>, <=lon>]`, `[51.5, -0.1]`, true, `{"lat": 51.5, "lon": -0.1}`},
+		{`[<=lat>, <=lon>]`, `[51.5, -0.1, 10]`, true, `{"lat": 51.5, "lon": -0.1}`},
+		{`[<=lat>, <=lon>]`, `[51.5]`, false, ``},
+		{`[1, <=x>]`, `[1, 2]`, true, `{"x": 2}`},
+		{`[1, <=x>]`, `[2, 2]`, false, ``},
+		{`[|<=lat>, <=lon>|]`, `[51.5, -0.1]`, true, `{"lat": 51.5, "lon": -0.1}`},
+		{`[|<=lat>, <=lon>|]`, `[51.5, -0.1, 10]`, false, ``},
+		{`[||]`, `[]`, true, `{}`},
+		{`[||]`, `[1]`, false, ``},
+		{`[<=x>, [<=y>, <x>]]`, `[1, [2, 1]]`, true, `{"x": 1, "y": 2}`},
+
+		{`[-1: <=last>]`, `[1, 2, 3]`, true, `{"last": 3}`},
+		{`[-3: <=first>]`, `[1, 2, 3]`, true, `{"first": 1}`},
+		{`[-4: <=x>]`, `[1, 2, 3]`, false, ``},
+		{`[-1?: <=last>]`, `[]`, true, `{}`},
+		{`[0: <=i>, <i>: <=x>]`, `[-1, 2, 3]`, true, `{"i": -1, "x": 3}`},
+		{`[1..: <=tail>]`, `[1, 2, 3]`, true, `{"tail": [2, 3]}`},
+		{`[1..: <=tail>]`, `[]`, true, `{"tail": []}`},
+		{`[2..5: <=window>]`, `[0, 1, 2, 3, 4, 5, 6]`, true, `{"window": [2, 3, 4, 5]}`},
+		{`[2..5: <=window>]`, `[0, 1, 2, 3]`, true, `{"window": [2, 3]}`},
+		{`[..-2: <=init>, -1: <=last>]`, `[1, 2, 3]`, true, `{"init": [1, 2], "last": 3}`},
+		{`[..-1: <=all>]`, `[1, 2, 3]`, true, `{"all": [1, 2, 3]}`},
+		{`[..-5: <=none>]`, `[1, 2, 3]`, true, `{"none": []}`},
+		{`[1..1: <=one>]`, `[1, 2, 3]`, true, `{"one": [2]}`},
+		{`[-2..: <=end>]`, `[1, 2, 3]`, true, `{"end": [2, 3]}`},
+		{`[3..1: <=x>]`, `[1, 2, 3, 4]`, true, `{"x": []}`},
+		{`[0: <=n>, 1..<n>: <=items>]`, `[3, "a", "b", "c"]`, true, `{"n": 3, "items": ["a", "b", "c"]}`},
+		{`[1..: [<=x>, <=y>]]`, `[0, 1, 2]`, true, `{"x": 1, "y": 2}`},
+		{`[1..: [|<=x>|]]`, `[0, 1, 2]`, false, ``},
+
+		{`[]#3`, `[1, 2, 3]`, true, `{}`},
+		{`[]#3`, `[1, 2]`, false, ``},
+		{`[]#0`, `[]`, true, `{}`},
+		{`[]#0`, `[1]`, false, ``},
+		{`[0: <=x>]#1..`, `[1, 2, 3]`, true, `{"x": 1}`},
+		{`[0?: <=x>]#..2`, `[1, 2, 3]`, false, ``},
+		{`[0?: <=x>]#..2`, `[]`, true, `{}`},
+		{`[<=x>, <=y>]#2..3`, `[1, 2, 3]`, true, `{"x": 1, "y": 2}`},
+		{`[<=x>, <=y>]#2..3`, `[1, 2, 3, 4]`, false, ``},
+		{`{"a": []#1..}`, `{"a": []}`, false, ``},
+
+		{`[*: {"id": <=ids>, "qty": number}]`, `[{"id": 1, "qty": 2}, {"id": 3, "qty": 4}]`, true, `{"ids": [1, 3]}`},
+		{`[*: {"id": <=ids>, "qty": number}]`, `[{"id": 1, "qty": 2}, {"id": 3, "qty": "4"}]`, false, ``},
+		{`[*: {"id": <=ids>, "qty": number}]`, `[]`, true, `{"ids": []}`},
+		{`{"p": <=p>, "xs": [*: {"p": <p>, "v": $"<p>-<=vs>"}]}`, `{"p": "a", "xs": []}`, true, `{"vs": [], "p": "a"}`},
+		{`[*: {"id": <=ids>, "note"?: <=notes>}]`, `[{"id": 1}, {"id": 2, "note": "x"}]`, true, `{"ids": [1, 2], "notes": [null, "x"]}`},
+		{`[*: number]`, `[1, 2, 3]`, true, `{}`},
+		{`[*: number]`, `[1, "2", 3]`, false, ``},
+		{`[0: <=first>, *: number]`, `[1, 2]`, true, `{"first": 1}`},
+		{`[0: <=max>, *: <max> | number]`, `[3, 1, 2]`, true, `{"max": 3}`},
+		{`[*: [*: <=xs>]]`, `[[1, 2], [3]]`, true, `{"xs": [[1, 2], [3]]}`},
+		{`{"orders": [*: {"id": <=ids>}], "first": <ids.0>}`, `{"orders": [{"id": "a"}, {"id": "b"}], "first": "a"}`, true, `{"ids": ["a", "b"]}`},
+
+		{`[?: {"key": "env", "value": <=env>}]`, `[{"key": "app", "value": "x"}, {"key": "env", "value": "prod"}]`, true, `{"env": "prod"}`},
+		{`[?: {"key": "env", "value": <=env>}]`, `[{"key": "app", "value": "x"}]`, false, ``},
+		{`[?: {"key": "env", "value": <=env>}]`, `[]`, false, ``},
+		{`[?<=i>: {"key": "env"}]`, `[{"key": "app"}, {"key": "env"}, {"key": "env"}]`, true, `{"i": 1}`},
+		{`[?: <=x> number]`, `["a", 2, 3]`, true, `{"x": 2}`},
+		{`{"want": <=w>, "tags": [?<=i>: <w>]}`, `{"want": "b", "tags": ["a", "b"]}`, true, `{"w": "b", "i": 1}`},
+		{`{"want": <=w>, "tags": [?<=i>: <w>]}`, `{"want": "c", "tags": ["a", "b"]}`, false, ``},
+		{`[?<=i>: "b", <i>: <=x>]`, `["a", "b"]`, true, `{"i": 1, "x": "b"}`},
+		{`[?: 1, ?: 2]`, `[2, 1]`, true, `{}`},
+		{`[?: 1, ?: 2]`, `[1, 1]`, false, ``},
+
+		{`[& "admin", "read"]`, `["read", "write", "admin"]`, true, `{}`},
+		{`[& "admin", "read"]`, `["read", "write"]`, false, ``},
+		{`[& "read", "read"]`, `["read", "write"]`, false, ``},
+		{`[& "read", "read"]`, `["read", "read"]`, true, `{}`},
+		{`[& number, 1]`, `[1, 2]`, true, `{}`},
+		{`[& <=x> number, <=y> 1]`, `[1, 2]`, true, `{"x": 2, "y": 1}`},
+		{`[& <=x> number, <x>]`, `[1, 2, 2]`, true, `{"x": 2}`},
+		{`[|& "admin", <=other>|]`, `["x", "admin"]`, true, `{"other": "x"}`},
+		{`[|& "admin", <=other>|]`, `["x", "admin", "y"]`, false, ``},
+		{`[& {"id": <=id>}]`, `[1, {"id": 3}]`, true, `{"id": 3}`},
+
 		{`[0: [0: <=x>], 1: <x>]`, `[[1], 1]`, true, `{"x": 1}`},
 		{`[0: [0: <=x>], 1: <x>]`, `[[1], 2]`, false, ``},
 	}
@@ -191,3 +778,117 @@ func TestInterpret(t *testing.T) {
 		})
 	}
 }
+
+func TestInterpretAll(t *testing.T) {
+	tests := []struct {
+		pattern   string
+		input     string
+		solutions string
+	}{
+		{`{"a": <=x>}`, `{"a": 1}`, `[{"x": 1}]`},
+		{`{"a": 1}`, `{"a": 1}`, `[{}]`},
+		{`1`, `1`, `[{}]`},
+
+		{`[?: <=x>]`, `[1, 2, 3]`, `[{"x": 1}, {"x": 2}, {"x": 3}]`},
+		{`[?<=i>: "a"]`, `["a", "b", "a"]`, `[{"i": 0}, {"i": 2}]`},
+		{`[?: "a"]`, `["a", "b", "a"]`, `[{}]`},
+		{`[?: <=x>, ?: <=y>]`, `[1, 2]`, `[{"x": 1, "y": 1}, {"x": 1, "y": 2}, {"x": 2, "y": 1}, {"x": 2, "y": 2}]`},
+		{`{"orders": [?: {"sku": "a", "n": <=n>}]}`, `{"orders": [{"sku": "a", "n": 1}, {"sku": "b", "n": 2}, {"sku": "a", "n": 3}]}`, `[{"n": 1}, {"n": 3}]`},
+
+		{`{"tags": [?: <=t>], "want": <t>}`, `{"tags": ["a", "b"], "want": "b"}`, `[{"t": "b"}]`},
+		{`[?: {"id": <=id>}, ?: {"parent": <id>}]`, `[{"id": 1}, {"id": 2}, {"parent": 2}]`, `[{"id": 2}]`},
+
+		{`<=x> 1 | <=x> number`, `1`, `[{"x": 1}]`},
+		{`{"a": <=x> number | <=x> 1}`, `{"a": 1}`, `[{"x": 1}]`},
+		{`[*: [?: <=x>]]`, `[[1, 2], [3]]`, `[{"x": [1, 3]}, {"x": [2, 3]}]`},
+		{`<=all> [?: <=x>]`, `[1, 2]`, `[{"all": [1, 2], "x": 1}, {"all": [1, 2], "x": 2}]`},
+		{`{"a": [?: <=x>], ...<=rest>}`, `{"a": [1, 2], "b": 3}`, `[{"x": 1, "rest": {"b": 3}}, {"x": 2, "rest": {"b": 3}}]`},
+		{`[& <=x>, <=y>]`, `[1, 2]`, `[{"x": 1, "y": 2}, {"x": 2, "y": 1}]`},
+		{`[& <=x> number, "a"]`, `["a", 1, 2]`, `[{"x": 1}, {"x": 2}]`},
+		{`[& "a", "a"]`, `["a", "a"]`, `[{}]`},
+		{`{"a": [?: <=x>], "b": [?: <=y>], "c": [<x>, <y>]}`, `{"a": [1, 2], "b": [3, 4], "c": [2, 4]}`, `[{"x": 2, "y": 4}]`},
+		{`[*: [?: _]]`, "[" + strings.Repeat(`[0, 1, 2, 3, 4, 5, 6, 7, 8, 9], `, 40) + "[0]]", `[{}]`},
+		{`[*: [?: <=x> 9]]`, "[" + strings.Repeat(`[0, 1, 2, 3, 4, 5, 6, 7, 8, 9], `, 40) + "[9]]", `[{"x": [` + strings.Repeat(`9, `, 40) + `9]}]`},
+		{`[& [?: _], [?: _], [?: _]]`, "[" + strings.Repeat(`[0, 1, 2, 3, 4, 5, 6, 7, 8, 9], `, 8) + "[0]]", `[{}]`},
+		{`..<=at> {"code": <=code>}`, `{"code": 1, "nested": [{"code": 2}, {"x": {"code": 3}}]}`, `[{"at": "", "code": 1}, {"at": "/nested/0", "code": 2}, {"at": "/nested/1/x", "code": 3}]`},
+		{`..{"code": <=code>}`, `{"a": {"code": 1}, "b": {"code": 1}}`, `[{"code": 1}]`},
+		{`..<=at> number`, `{"a": [1, {"b": 2}], "c": "x"}`, `[{"at": "/a/0"}, {"at": "/a/1/b"}]`},
+		{`{*<=k>: [?: <=x>]}`, `{"a": [1, 2], "b": [3]}`, `[{"k": ["a", "b"], "x": [1, 3]}, {"k": ["a", "b"], "x": [2, 3]}]`},
+	}
+
+	for _, test := range tests {
+		name := test.pattern + " -> " + test.input
+		t.Run(name, func(t *testing.T) {
+			p, err := pattern.Parse(test.pattern)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			solutions, err := p.InterpretAll(test.input)
+			if err != nil {
+				t.Fatalf(`'%s' failed to match: %s`, name, err)
+			}
+
+			var expected []interface{}
+			err = json.Unmarshal([]byte(test.solutions), &expected)
+			if err != nil {
+				t.Fatalf(`bad test solutions '%s': %s`, test.solutions, err)
+			}
+
+			actual := []interface{}{}
+			for _, b := range solutions {
+				actual = append(actual, b)
+			}
+
+			if !pattern.Matches(expected, actual) {
+				eb, _ := json.Marshal(expected)
+				ab, _ := json.Marshal(actual)
+
+				t.Fatalf("'%s' did not match: \n'%s' != \n'%s'", name, string(eb), string(ab))
+			}
+
+			first, err := p.Interpret(test.input)
+			if err != nil {
+				t.Fatalf(`'%s' failed to match the first solution: %s`, name, err)
+			}
+
+			if !pattern.Matches(map[string]interface{}(first), actual[0]) {
+				t.Fatalf("'%s' did not match the first solution: '%v' != '%v'", name, first, actual[0])
+			}
+		})
+	}
+}
+
+func TestUnparsedQuantifiers(t *testing.T) {
+	tests := []struct {
+		pattern  pattern.Pattern
+		input    string
+		expected string
+	}{
+		{
+			pattern.Array{Elements: []pattern.Element{{Index: pattern.Every{Names: []string{"x"}}, Value: pattern.Binding("x")}}},
+			`[]`, `{"x": []}`,
+		},
+		{
+			pattern.Object{Fields: []pattern.Field{{Key: pattern.Entries{Pattern: pattern.Binding("k"), Names: []string{"k", "v"}}, Value: pattern.Binding("v")}}},
+			`{}`, `{"k": [], "v": []}`,
+		},
+	}
+
+	for _, test := range tests {
+		name := fmt.Sprintf("%s -> %s", test.pattern, test.input)
+		t.Run(name, func(t *testing.T) {
+			b, err := test.pattern.Interpret(test.input)
+			if err != nil {
+				t.Fatalf(`'%s' failed to match: %s`, name, err)
+			}
+
+			var expected interface{}
+			json.Unmarshal([]byte(test.expected), &expected)
+
+			if !pattern.Matches(expected, map[string]interface{}(b)) {
+				t.Fatalf("'%s' did not match: '%s' != '%v'", name, test.expected, b)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package pattern
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// Range matches numbers between two inclusive bounds, either of which may
+// be a reference to a bound number. An absent optional reference leaves its
+// end of the range open.
+type Range struct {
+	From, To Index
+}
+
+func (r Range) Match(s []byte, b bindings) (bindings, error) {
+	n, err := number(s)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := limit(r.From, b, math.Inf(-1))
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := limit(r.To, b, math.Inf(1))
+	if err != nil {
+		return nil, err
+	}
+
+	if n < from || n > to {
+		return nil, fmt.Errorf("expected value in [%s, %s] but found %s", Number(from), Number(to), s)
+	}
+
+	return bindings{}, nil
+}
+
+func (r Range) Validate(s set) error {
+	for _, i := range []Index{r.From, r.To} {
+		if bound, ok := i.(Validator); ok {
+			if err := bound.Validate(s); err != nil {
+				return fmt.Errorf("in range %s: %s", r, err)
+			}
+		}
+	}
+
+	from, fromLiteral := r.From.(Number)
+	to, toLiteral := r.To.(Number)
+	if fromLiteral && toLiteral && to < from {
+		return fmt.Errorf("range %s is empty", r)
+	}
+
+	return nil
+}
+
+func (r Range) String() string {
+	return r.From.String() + ".." + r.To.String()
+}
+
+// number reads a matched value as a number for comparison against bounds,
+// rejecting null which would otherwise decode as zero.
+func number(s []byte) (float64, error) {
+	var x interface{}
+	err := json.Unmarshal(s, &x)
+
+	n, ok := x.(float64)
+	if err != nil || !ok {
+		return 0, fmt.Errorf("expected number but matched value %s could not be interpreted as a number", s)
+	}
+
+	return n, nil
+}
+
+// limit resolves a bound to the number it stands for, or to the open bound
+// when it is an optional reference that is absent.
+func limit(i Index, b bindings, open float64) (float64, error) {
+	r, ok := i.(Reference)
+	if !ok {
+		return float64(i.(Number)), nil
+	}
+
+	y, found, err := r.resolve(b)
+	if err != nil {
+		return 0, err
+	}
+
+	if !found {
+		return open, nil
+	}
+
+	n, ok := y.(float64)
+	if !ok {
+		yb, _ := json.Marshal(y)
+		return 0, fmt.Errorf("bound reference %s must be a number but was '%s'", r, yb)
+	}
+
+	return n, nil
+}
@@ -3,18 +3,21 @@ package pattern
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 )
 
 func (r Reference) String() string {
 	s := "<"
 
 	for i, identifier := range r {
+		if i > 0 {
+			s += "."
+		}
+
 		s += string(identifier.Identifier)
 
 		if identifier.Optional {
 			s += "?"
-		} else if i != len(r)-1 {
-			s += "."
 		}
 	}
 
@@ -26,27 +29,67 @@ func (r Reference) String() string {
 func (r Reference) Validate(s set) error {
 	ref := string(r[0].Identifier)
 
-	if !s[ref] {
+	if !s[ref] && !s[anyName] {
 		return fmt.Errorf("reference to %s before it was bound", r)
 	}
 
 	return nil
 }
 
-func (r Reference) Match(s []byte, b bindings) (bindings, error) {
-	ref := string(r[0].Identifier)
+func (r Reference) Key(b bindings) (string, error) {
+	y, found, err := r.resolve(b)
+	if err != nil {
+		return "", err
+	}
 
-	y, exists := b[ref]
-	if !exists {
-		return nil, fmt.Errorf("referenced binding %s was not available, was it matched in an optional section?", r)
+	if !found {
+		return "", fmt.Errorf("key reference %s was not available", r)
+	}
+
+	key, ok := y.(string)
+	if !ok {
+		yb, _ := json.Marshal(y)
+		return "", fmt.Errorf("key reference %s must be a string but was '%s'", r, yb)
+	}
+
+	return key, nil
+}
+
+func (r Reference) Index(b bindings) (int, error) {
+	y, found, err := r.resolve(b)
+	if err != nil {
+		return 0, err
+	}
+
+	if !found {
+		return 0, fmt.Errorf("index reference %s was not available", r)
+	}
+
+	index, ok := y.(float64)
+	if !ok {
+		yb, _ := json.Marshal(y)
+		return 0, fmt.Errorf("index reference %s must be a number but was '%s'", r, yb)
+	}
+
+	return Number(index).Index(b)
+}
+
+func (r Reference) Match(s []byte, b bindings) (bindings, error) {
+	y, found, err := r.resolve(b)
+	if err != nil {
+		return nil, err
 	}
 
 	var x interface{}
-	err := json.Unmarshal(s, &x)
+	err = json.Unmarshal(s, &x)
 	if err != nil {
 		return nil, fmt.Errorf(`could not unmarshal bound value to match: %s`, err)
 	}
 
+	if !found {
+		return bindings{}, nil
+	}
+
 	if !Matches(x, y) {
 		xb, _ := json.Marshal(x)
 		yb, _ := json.Marshal(y)
@@ -56,6 +99,68 @@ func (r Reference) Match(s []byte, b bindings) (bindings, error) {
 	return bindings{}, nil
 }
 
+// resolve looks up the value the reference points to, following each
+// segment after the first through the members of bound objects or the
+// elements of bound arrays. When a segment marked optional is absent the
+// reference is not found, which is reported without an error.
+func (r Reference) resolve(b bindings) (interface{}, bool, error) {
+	first := r[0]
+
+	y, exists := b[string(first.Identifier)]
+	if !exists {
+		if first.Optional {
+			return nil, false, nil
+		}
+
+		return nil, false, fmt.Errorf("referenced binding %s was not available, was it matched in an optional section?", r)
+	}
+
+	for i, segment := range r[1:] {
+		var err error
+		y, err = member(y, string(segment.Identifier))
+		if err != nil {
+			if segment.Optional {
+				return nil, false, nil
+			}
+
+			return nil, false, fmt.Errorf("could not resolve %s, %s %s", r, r[:i+1], err)
+		}
+	}
+
+	return y, true, nil
+}
+
+func member(y interface{}, name string) (interface{}, error) {
+	if v, ok := y.(json.RawMessage); ok {
+		json.Unmarshal(v, &y)
+	}
+
+	switch v := y.(type) {
+	case map[string]interface{}:
+		y, exists := v[name]
+		if !exists {
+			return nil, fmt.Errorf("had no field %s", String(name))
+		}
+
+		return y, nil
+
+	case []interface{}:
+		index, err := strconv.Atoi(name)
+		if err != nil {
+			return nil, fmt.Errorf("is an array and %s is not an index", name)
+		}
+
+		if index < 0 || index >= len(v) {
+			return nil, fmt.Errorf("had no index %d", index)
+		}
+
+		return v[index], nil
+
+	default:
+		return nil, fmt.Errorf("is not an object or array")
+	}
+}
+
 func Matches(a, b interface{}) bool {
 	if v, ok := a.(json.RawMessage); ok {
 		json.Unmarshal(v, &a)
@@ -0,0 +1,60 @@
+package pattern
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Regex matches strings against a regular expression, binding each named
+// capture group to the text it captured, or null if it took no part.
+type Regex struct {
+	Expr *regexp.Regexp
+}
+
+func (r Regex) Match(s []byte, _ bindings) (bindings, error) {
+	x, err := text(s)
+	if err != nil {
+		return nil, err
+	}
+
+	submatches := r.Expr.FindStringSubmatchIndex(x)
+	if submatches == nil {
+		return nil, fmt.Errorf(`expected string matching %s but found %s`, r, s)
+	}
+
+	bNew := bindings{}
+	for i, name := range r.Expr.SubexpNames() {
+		if name == "" {
+			continue
+		}
+
+		start, end := submatches[2*i], submatches[2*i+1]
+		if start < 0 {
+			bNew[name] = nil
+			continue
+		}
+
+		bNew[name] = x[start:end]
+	}
+
+	return bNew, nil
+}
+
+func (r Regex) Validate(s set) error {
+	for _, name := range r.Expr.SubexpNames() {
+		if name == "" {
+			continue
+		}
+
+		if err := Binding(name).Validate(s); err != nil {
+			return fmt.Errorf("capture group in %s: %s", r, err)
+		}
+	}
+
+	return nil
+}
+
+func (r Regex) String() string {
+	return "/" + strings.ReplaceAll(r.Expr.String(), "/", `\/`) + "/"
+}
@@ -0,0 +1,45 @@
+package pattern
+
+import "bytes"
+
+// Root adapts a value so that it can be matched against a whole document,
+// allowing scalars and bindings to be used as patterns as well as objects
+// and arrays.
+type Root struct {
+	Value Value
+}
+
+func (r Root) Interpret(s string) (bindings, error) {
+	b, err := r.Value.Match(bytes.TrimSpace([]byte(s)), bindings{})
+	if err != nil {
+		return nil, err
+	}
+
+	if b == nil {
+		b = bindings{}
+	}
+
+	return b, nil
+}
+
+func (r Root) InterpretAll(s string) ([]bindings, error) {
+	solutions, err := allMatches.match(r.Value, bytes.TrimSpace([]byte(s)), bindings{})
+	if err != nil {
+		return nil, err
+	}
+
+	return distinct(solutions), nil
+}
+
+func (r Root) Validate(s set) error {
+	value, ok := r.Value.(Validator)
+	if !ok {
+		return nil
+	}
+
+	return value.Validate(s)
+}
+
+func (r Root) String() string {
+	return r.Value.String()
+}
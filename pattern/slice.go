@@ -0,0 +1,169 @@
+package pattern
+
+// Slice selects a sub-array between two inclusive bounds, either of which
+// may be omitted to extend the slice to the start or end of the array.
+// Negative bounds count back from the end of the array.
+type Slice struct {
+	From, To Index
+}
+
+// Index of a slice is its starting bound
+func (s Slice) Index(b bindings) (int, error) {
+	return bound(s.From, b, 0)
+}
+
+// Bounds resolves the slice against an array of the given length, returning
+// the start and the exclusive end of the sub-array clamped to the array.
+func (s Slice) Bounds(b bindings, length int) (int, int, error) {
+	from, err := bound(s.From, b, 0)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	to, err := bound(s.To, b, -1)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// the last element is included, so the end is the position after it
+	if to < 0 {
+		to += length
+	}
+
+	from, to = clamp(from, length), to+1
+	if to > length {
+		to = length
+	}
+
+	if to < from {
+		to = from
+	}
+
+	return from, to, nil
+}
+
+func bound(i Index, b bindings, open int) (int, error) {
+	if i == nil {
+		return open, nil
+	}
+
+	return i.Index(b)
+}
+
+func clamp(i, length int) int {
+	if i < 0 {
+		i += length
+	}
+
+	if i < 0 {
+		return 0
+	}
+
+	if i > length {
+		return length
+	}
+
+	return i
+}
+
+func (s Slice) Validate(set set) error {
+	for _, i := range []Index{s.From, s.To} {
+		if n, ok := i.(Number); ok {
+			if _, err := n.Index(nil); err != nil {
+				return err
+			}
+		}
+
+		if v, ok := i.(Validator); ok {
+			if err := v.Validate(set); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s Slice) String() string {
+	str := ""
+	if s.From != nil {
+		str += s.From.String()
+	}
+
+	str += ".."
+
+	if s.To != nil {
+		str += s.To.String()
+	}
+
+	return str
+}
+
+// position is a literal slice bound within an arbitrarily long array, where
+// every position counted from the start precedes every position counted
+// from the end.
+type position struct {
+	fromEnd bool
+	offset  int
+}
+
+func (p position) less(q position) bool {
+	if p.fromEnd != q.fromEnd {
+		return !p.fromEnd
+	}
+
+	return p.offset < q.offset
+}
+
+func literal(i Index, open position) (position, bool) {
+	if i == nil {
+		return open, true
+	}
+
+	n, ok := i.(Number)
+	if !ok {
+		return position{}, false
+	}
+
+	index, err := n.Index(nil)
+	if err != nil {
+		return position{}, false
+	}
+
+	return position{fromEnd: index < 0, offset: index}, true
+}
+
+// overlaps reports whether both slices would select a common element of a
+// long enough array, which can only be known when their bounds are literals.
+func (s Slice) overlaps(t Slice) bool {
+	start, end := position{fromEnd: false}, position{fromEnd: true}
+
+	sFrom, sFromOk := literal(s.From, start)
+	sTo, sToOk := literal(s.To, end)
+	tFrom, tFromOk := literal(t.From, start)
+	tTo, tToOk := literal(t.To, end)
+	if !sFromOk || !sToOk || !tFromOk || !tToOk {
+		return false
+	}
+
+	// compare the positions after the last elements, so that the ends are
+	// exclusive like the open end of the array
+	if s.To != nil {
+		sTo.offset++
+	}
+
+	if t.To != nil {
+		tTo.offset++
+	}
+
+	from, to := sFrom, sTo
+	if from.less(tFrom) {
+		from = tFrom
+	}
+
+	if tTo.less(to) {
+		to = tTo
+	}
+
+	return from.less(to)
+}
@@ -0,0 +1,250 @@
+package pattern
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Solver is implemented by values that can match an input in more than one
+// way, Solve returns the bindings of every way the value matches.
+type Solver interface {
+	Solve([]byte, bindings) ([]bindings, error)
+}
+
+// mode selects whether matching searches lazily for the first way a value
+// matches, backtracking only as far as it needs to, or enumerates every way
+// it can match. Both find solutions in the same order, so the first solution
+// is the same either way.
+type mode int
+
+const (
+	firstMatch mode = iota
+	allMatches
+)
+
+// continuation is given each way a value matched, returning nil to accept
+// the solution and end the search or an error to backtrack to the next one.
+type continuation func(bindings) error
+
+// backtracker is implemented by values made of parts that are matched one
+// after another, which can resume their search when a later part fails.
+type backtracker interface {
+	each([]byte, bindings, mode, continuation) error
+}
+
+// errMore is returned by continuations that collect every solution, to keep
+// the search going after each one.
+var errMore = fmt.Errorf("looking for more solutions")
+
+func (m mode) match(v Value, s []byte, b bindings) ([]bindings, error) {
+	if solver, ok := v.(Solver); ok && m == allMatches {
+		solutions, err := solver.Solve(s, b)
+		if err != nil {
+			return nil, err
+		}
+
+		return distinct(solutions), nil
+	}
+
+	matched, err := v.Match(s, b)
+	if err != nil {
+		return nil, err
+	}
+
+	if matched == nil {
+		matched = bindings{}
+	}
+
+	return []bindings{matched}, nil
+}
+
+// each gives each way the value matches to the continuation, until it
+// accepts one.
+func (m mode) each(v Value, s []byte, b bindings, k continuation) error {
+	if bt, ok := v.(backtracker); ok {
+		return bt.each(s, b, m, k)
+	}
+
+	matches, err := m.match(v, s, b)
+	if err != nil {
+		return err
+	}
+
+	return feed(matches, k)
+}
+
+// feed gives each solution to the continuation, until it accepts one.
+func feed(solutions []bindings, k continuation) error {
+	failure := fmt.Errorf("no way to match")
+	for _, solution := range solutions {
+		err := k(solution)
+		if err == nil {
+			return nil
+		}
+
+		failure = err
+	}
+
+	return failure
+}
+
+// first returns the first way the value matches.
+func first(bt backtracker, s []byte, b bindings) (bindings, error) {
+	var solution bindings
+	err := bt.each(s, b, firstMatch, func(bNew bindings) error {
+		solution = bNew
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return solution, nil
+}
+
+// all returns every distinct way the value matches.
+func all(bt backtracker, s []byte, b bindings) ([]bindings, error) {
+	solutions := []bindings{}
+	err := bt.each(s, b, allMatches, func(bNew bindings) error {
+		solutions = append(solutions, bNew)
+		return errMore
+	})
+	if len(solutions) == 0 {
+		return nil, err
+	}
+
+	return distinct(solutions), nil
+}
+
+// within matches a part of a value, describing the part's own failures with
+// the prefix while passing the failures of the continuation on unchanged.
+func within(prefix string, part func(continuation) error, k continuation) error {
+	var downstream error
+	err := part(func(b bindings) error {
+		downstream = k(b)
+		return downstream
+	})
+
+	if err != nil && err != downstream {
+		return fmt.Errorf("%s: %s", prefix, err)
+	}
+
+	return err
+}
+
+// step matches one of a sequence of parts, giving each way it matches to the
+// continuation.
+type step func(i int, b bindings, m mode, k continuation) error
+
+// search matches a sequence of steps, each of which can see the bindings
+// made by the steps before it, giving the new bindings of each way that all
+// of the steps can be matched together to the continuation.
+func search(bOld bindings, steps int, s step, m mode, k continuation) error {
+	if m == firstMatch {
+		return backtrack(bOld, bindings{}, 0, steps, s, k, set{})
+	}
+
+	paths := []bindings{{}}
+
+	for i := 0; i < steps; i++ {
+		failure := fmt.Errorf("no way to match")
+		next := []bindings{}
+
+		for _, bNew := range paths {
+			err := s(i, merge(bOld, bNew), allMatches, func(matched bindings) error {
+				extended, err := extend(bOld, bNew, matched)
+				if err != nil {
+					return err
+				}
+
+				next = append(next, extended)
+				return errMore
+			})
+
+			if err != nil && err != errMore {
+				failure = err
+			}
+		}
+
+		if len(next) == 0 {
+			return failure
+		}
+
+		paths = distinct(next)
+	}
+
+	return feed(paths, k)
+}
+
+// backtrack searches the steps depth first, resuming the search at the
+// latest step whenever the continuation rejects a solution. Paths that have
+// already been tried are skipped, as they would be rejected again.
+func backtrack(bOld, bNew bindings, i, steps int, s step, k continuation, tried set) error {
+	if i == steps {
+		return k(bNew)
+	}
+
+	key, _ := json.Marshal(bNew)
+	path := fmt.Sprintf("%d:%s", i, key)
+	if tried[path] {
+		return fmt.Errorf("no way to match")
+	}
+	tried[path] = true
+
+	return s(i, merge(bOld, bNew), firstMatch, func(matched bindings) error {
+		extended, err := extend(bOld, bNew, matched)
+		if err != nil {
+			return err
+		}
+
+		return backtrack(bOld, extended, i+1, steps, s, k, tried)
+	})
+}
+
+func merge(a, b bindings) bindings {
+	merged := bindings{}
+	for k, v := range a {
+		merged[k] = v
+	}
+
+	for k, v := range b {
+		merged[k] = v
+	}
+
+	return merged
+}
+
+// extend adds the matched bindings to bNew, failing if any of them would
+// overwrite a binding that already exists.
+func extend(bOld, bNew, matched bindings) (bindings, error) {
+	extended := merge(bNew, nil)
+
+	for k, v := range matched {
+		_, k_old := bOld[k]
+		if _, k_new := bNew[k]; k_old || k_new {
+			return nil, fmt.Errorf("binding for %s already exists and cannot be overwritten", k)
+		}
+
+		extended[k] = v
+	}
+
+	return extended, nil
+}
+
+// distinct removes duplicate solutions, keeping the first of each.
+func distinct(solutions []bindings) []bindings {
+	seen := set{}
+	unique := []bindings{}
+
+	for _, b := range solutions {
+		key, _ := json.Marshal(b)
+		if seen[string(key)] {
+			continue
+		}
+
+		seen[string(key)] = true
+		unique = append(unique, b)
+	}
+
+	return unique
+}
@@ -0,0 +1,77 @@
+package pattern
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Some is the index of an element that searches an array for the first
+// element matching its value, optionally binding the position it was found
+// at. Every matching element is found, so that a search can backtrack to a
+// later element when an earlier one leads nowhere.
+type Some struct {
+	Position *Binding
+}
+
+func (Some) Index(_ bindings) (int, error) {
+	return 0, fmt.Errorf("some element quantifier does not address a single index")
+}
+
+func (q Some) match(v Value, input []json.RawMessage, b bindings, m mode, k continuation) error {
+	failure := fmt.Errorf("none of the %d elements matched %s", len(input), v)
+
+	// elements often match the same way, which need only be tried once
+	tried := map[string]error{}
+
+	for i, element := range input {
+		var downstream error
+		err := m.each(v, element, b, func(matched bindings) error {
+			bNew := merge(matched, nil)
+			if q.Position != nil {
+				bNew[string(*q.Position)] = float64(i)
+			}
+
+			key, _ := json.Marshal(bNew)
+			if rejected, ok := tried[string(key)]; ok {
+				downstream = rejected
+				return rejected
+			}
+
+			downstream = k(bNew)
+			tried[string(key)] = downstream
+			return downstream
+		})
+
+		if err == nil {
+			return nil
+		}
+
+		if err == downstream {
+			failure = err
+		}
+	}
+
+	return failure
+}
+
+func (q Some) validate(v Value, s set) error {
+	if value, ok := v.(Validator); ok {
+		if err := value.Validate(s); err != nil {
+			return err
+		}
+	}
+
+	if q.Position != nil {
+		return q.Position.Validate(s)
+	}
+
+	return nil
+}
+
+func (q Some) String() string {
+	if q.Position != nil {
+		return "?" + q.Position.String()
+	}
+
+	return "?"
+}
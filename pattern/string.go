@@ -1,25 +1,56 @@
 package pattern
 
-import "fmt"
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
 
 type String string
 
-func (k String) Key() (string, error) {
+func (k String) Key(_ bindings) (string, error) {
 	return string(k), nil
 }
 
 func (s String) String() string {
-	return string(s)
+	return quote(string(s))
 }
 
 func (t String) Match(s []byte, _ bindings) (bindings, error) {
-	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
-		return nil, fmt.Errorf(`value '%s' could not be interpreted as a string`, s)
+	x, err := text(s)
+	if err != nil {
+		return nil, err
 	}
 
-	if string(t) != string(s[1:len(s)-1]) {
-		return nil, fmt.Errorf(`expected '%s' but matched value '%s'`, t, s)
+	if string(t) != x {
+		return nil, fmt.Errorf(`expected %s but matched value %s`, t, s)
 	}
 
 	return nil, nil
 }
+
+// text decodes a matched value that must be a json string, rejecting null
+// which would otherwise decode as the empty string.
+func text(s []byte) (string, error) {
+	var x interface{}
+	err := json.Unmarshal(s, &x)
+
+	str, ok := x.(string)
+	if err != nil || !ok {
+		return "", fmt.Errorf(`value '%s' could not be interpreted as a string`, s)
+	}
+
+	return str, nil
+}
+
+// quote escapes s as a JSON string literal, leaving html characters intact
+// so that printed patterns read the same as they were written.
+func quote(s string) string {
+	var b bytes.Buffer
+
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+
+	return string(bytes.TrimSuffix(b.Bytes(), []byte("\n")))
+}
@@ -0,0 +1,213 @@
+package pattern
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Template matches strings made of literal segments and placeholders, written
+// as $"order-<=id>-<ref>". Each binding placeholder takes the shortest text
+// that lets the rest match and each reference placeholder matches the text of
+// the value it refers to, or any text when an optional reference is absent.
+type Template []Value
+
+func (t Template) Match(s []byte, b bindings) (bindings, error) {
+	x, err := text(s)
+	if err != nil {
+		return nil, err
+	}
+
+	expr := "(?s)^"
+	names := []string{}
+	for _, segment := range t {
+		switch segment := segment.(type) {
+		case String:
+			expr += regexp.QuoteMeta(string(segment))
+
+		case Binding:
+			expr += "(.*?)"
+			names = append(names, string(segment))
+
+		case Reference:
+			text, found, err := segment.text(b)
+			if err != nil {
+				return nil, err
+			}
+
+			if !found {
+				expr += ".*?"
+				continue
+			}
+
+			expr += regexp.QuoteMeta(text)
+		}
+	}
+	expr += "$"
+
+	submatches := regexp.MustCompile(expr).FindStringSubmatch(x)
+	if submatches == nil {
+		return nil, fmt.Errorf(`expected string matching template %s but found %s`, t, s)
+	}
+
+	bNew := bindings{}
+	for i, name := range names {
+		bNew[name] = submatches[i+1]
+	}
+
+	return bNew, nil
+}
+
+// text resolves the reference to the text it stands for within a template,
+// strings standing for themselves and other values for their json encoding.
+func (r Reference) text(b bindings) (string, bool, error) {
+	y, found, err := r.resolve(b)
+	if err != nil || !found {
+		return "", false, err
+	}
+
+	if text, ok := y.(string); ok {
+		return text, true, nil
+	}
+
+	yb, err := json.Marshal(y)
+	if err != nil {
+		return "", false, err
+	}
+
+	return string(yb), true, nil
+}
+
+func (t Template) Validate(s set) error {
+	// references are checked first so that they cannot see the template's
+	// own bindings, which are not available until the whole string matches
+	for _, segment := range t {
+		if ref, ok := segment.(Reference); ok {
+			if err := ref.Validate(s); err != nil {
+				return fmt.Errorf("in template %s: %s", t, err)
+			}
+		}
+	}
+
+	// the text between two bindings with no literal text to divide it could
+	// be split between them in any way
+	var previous *Binding
+	for _, segment := range t {
+		switch segment := segment.(type) {
+		case String:
+			previous = nil
+
+		case Binding:
+			if previous != nil {
+				return fmt.Errorf("in template %s: bindings %s and %s must be separated by literal text", t, previous, segment)
+			}
+			previous = &segment
+
+			if err := segment.Validate(s); err != nil {
+				return fmt.Errorf("in template %s: %s", t, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (t Template) String() string {
+	s := `$"`
+	for _, segment := range t {
+		if literal, ok := segment.(String); ok {
+			s += escapePlaceholders(strings.TrimSuffix(strings.TrimPrefix(literal.String(), `"`), `"`))
+			continue
+		}
+
+		s += segment.String()
+	}
+
+	return s + `"`
+}
+
+// placeholder reads the binding or reference at the start of a string, if
+// there is one, returning it with the number of runes it spans.
+func placeholder(input []rune) (Value, int) {
+	i := 0
+	next := func() rune {
+		if i < len(input) {
+			return input[i]
+		}
+
+		return EOF
+	}
+
+	name := func() (Identifier, bool) {
+		start := i
+		for unicode.IsLetter(next()) || unicode.IsNumber(next()) {
+			i++
+		}
+
+		s := string(input[start:i])
+		token, keyword := keywords[s]
+		return Identifier(s), s != "" && (!keyword || token == TYPE)
+	}
+
+	if next() != '<' {
+		return nil, 0
+	}
+	i++
+
+	if next() == '=' {
+		i++
+
+		identifier, ok := name()
+		if !ok || next() != '>' {
+			return nil, 0
+		}
+
+		return Binding(identifier), i + 1
+	}
+
+	r := Reference{}
+	for {
+		identifier, ok := name()
+		if !ok {
+			return nil, 0
+		}
+
+		segment := OptionalIdentifier{Identifier: identifier}
+		if next() == '?' {
+			segment.Optional = true
+			i++
+		}
+
+		r = append(r, segment)
+
+		if next() != '.' {
+			break
+		}
+		i++
+	}
+
+	if next() != '>' {
+		return nil, 0
+	}
+
+	return r, i + 1
+}
+
+// escapePlaceholders escapes each < in a template literal that would
+// otherwise be read back as the start of a placeholder.
+func escapePlaceholders(s string) string {
+	input := []rune(s)
+
+	var b strings.Builder
+	for i, c := range input {
+		if _, n := placeholder(input[i:]); n > 0 {
+			b.WriteRune('\\')
+		}
+
+		b.WriteRune(c)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,70 @@
+package pattern
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+type Type string
+
+const (
+	NullType    Type = "null"
+	StringType  Type = "string"
+	NumberType  Type = "number"
+	IntegerType Type = "integer"
+	BooleanType Type = "boolean"
+	ObjectType  Type = "object"
+	ArrayType   Type = "array"
+)
+
+func (t Type) Match(s []byte, _ bindings) (bindings, error) {
+	var x interface{}
+	err := json.Unmarshal(s, &x)
+	if err != nil {
+		return nil, fmt.Errorf("expected %s but matched value '%s' could not be interpreted as json", t, s)
+	}
+
+	found := typeOf(x)
+	if found == NumberType && t == IntegerType {
+		if n := x.(float64); math.Trunc(n) != n {
+			return nil, fmt.Errorf("expected %s but found fractional number %s", t, s)
+		}
+
+		return bindings{}, nil
+	}
+
+	if found == t {
+		return bindings{}, nil
+	}
+
+	value := ""
+	if len(s) < 10 {
+		value = " " + string(s)
+	}
+
+	return nil, fmt.Errorf("expected %s but found %s%s", t, found, value)
+}
+
+func (t Type) String() string {
+	return string(t)
+}
+
+func typeOf(x interface{}) Type {
+	switch x.(type) {
+	case map[string]interface{}:
+		return ObjectType
+	case []interface{}:
+		return ArrayType
+	case string:
+		return StringType
+	case float64:
+		return NumberType
+	case bool:
+		return BooleanType
+	case nil:
+		return NullType
+	default:
+		panic(fmt.Sprintf(`impossible type %T`, x))
+	}
+}
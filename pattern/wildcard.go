@@ -0,0 +1,20 @@
+package pattern
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type Wildcard struct{}
+
+func (Wildcard) Match(s []byte, _ bindings) (bindings, error) {
+	if !json.Valid(s) {
+		return nil, fmt.Errorf("value '%s' could not be interpreted as json", s)
+	}
+
+	return bindings{}, nil
+}
+
+func (Wildcard) String() string {
+	return "_"
+}